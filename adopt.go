@@ -0,0 +1,71 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// Check if a child is one the reaper knows about already: launched by it
+// or registered with it.
+func (r *Reaper) familiar(pid int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, launched := r.waiters[pid]
+	_, claimed := r.claimed[pid]
+	return launched || claimed
+
+} /*  End of method  Reaper.familiar.  */
+
+// Look for orphans that were re-parented to us since the last scan, i.e.
+// children of ours in the process tree that weren't around last time.
+// The first scan just takes stock.
+func (r *Reaper) scanAdoptions(tree []Child) {
+	if !r.config.DetectAdoptions {
+		return
+	}
+
+	self := os.Getpid()
+	baseline := nil == r.known
+	known := make(map[int]struct{}, len(r.known))
+	for _, child := range tree {
+		if self != child.Parent {
+			continue
+		}
+
+		known[child.Pid] = struct{}{}
+		if _, ok := r.known[child.Pid]; ok || baseline || r.familiar(child.Pid) {
+			continue
+		}
+
+		r.adopted(child)
+	}
+
+	r.known = known
+
+} /*  End of method  Reaper.scanAdoptions.  */
+
+// Report an orphan that was re-parented to us.
+func (r *Reaper) adopted(child Child) {
+	cmdline, _ := readProcCmdline(child.Pid)
+	event := r.dispatch(ReapEvent{
+		Type:    EventAdopted,
+		Pid:     child.Pid,
+		Time:    time.Now(),
+		Orphan:  true,
+		Comm:    child.Comm,
+		Cmdline: cmdline,
+	})
+
+	level.Info(r.config.Logger).Log("msg", "adopted orphan", "pid", child.Pid, "comm", child.Comm, "cmdline", cmdline, "seq", event.Seq)
+
+	if r.config.OnAdopted != nil {
+		r.config.OnAdopted(event)
+	}
+
+} /*  End of method  Reaper.adopted.  */
@@ -0,0 +1,133 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+// Look at the process tree far more often than every second.
+func fastChildScans(t *testing.T) {
+	saved := childScanInterval
+	childScanInterval = 10 * time.Millisecond
+	t.Cleanup(func() { childScanInterval = saved })
+
+} /*  End of function  fastChildScans.  */
+
+// Kill a child and wait for it to be gone, so that no zombie is left for
+// the reapers of later tests to find. Whoever reaps it, we or a reaper.
+func killChild(pid int) {
+	syscall.Kill(pid, syscall.SIGKILL)
+	syscall.Wait4(pid, nil, 0, nil)
+
+} /*  End of function  killChild.  */
+
+// Get the reaper into a sweep that waits on a living child - the default
+// Options block - returns that child's pid.
+func blockSweep(t *testing.T, r *Reaper, events <-chan ReapEvent) int {
+	t.Helper()
+
+	keeper := spawn(t, "exec sleep 10")
+	t.Cleanup(func() { killChild(keeper) })
+
+	reapOf(t, events, spawn(t, "exit 0"))
+	return keeper
+
+} /*  End of function  blockSweep.  */
+
+func TestDetectAdoptions(t *testing.T) {
+	if err := setSubreaper(); err != nil {
+		t.Skipf("no orphans to adopt without a subreaper: %v", err)
+	}
+	fastChildScans(t)
+
+	r := startTestReaper(t, Config{DetectAdoptions: true})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	keeper := blockSweep(t, r, events)
+
+	/*  Its parent gone, the background sleep is ours.  */
+	parent := spawn(t, "sleep 10 & sleep 0.1; exit 0")
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if EventAdopted != event.Type || keeper == event.Pid || parent == event.Pid {
+				continue
+			}
+			killChild(event.Pid)
+			if "sleep" != event.Comm || !event.Orphan {
+				t.Errorf("adopted %d (%s), orphan %v, expected the sleep", event.Pid, event.Comm, event.Orphan)
+			}
+			return
+		case <-timeout:
+			t.Fatalf("timed out waiting for the orphan to be adopted")
+		}
+	}
+
+} /*  End of function  TestDetectAdoptions.  */
+
+func TestTrackChildren(t *testing.T) {
+	fastChildScans(t)
+
+	r := startTestReaper(t, Config{TrackChildren: true})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	blockSweep(t, r, events)
+
+	/*  No SIGCHLD to wake up to until it dies, yet it shows up.  */
+	pid := spawn(t, "exec sleep 10")
+	eventually(t, "the new child in the tree", func() bool {
+		for _, child := range r.Children() {
+			if pid == child.Pid {
+				return true
+			}
+		}
+		return false
+	})
+
+	/*  And it is gone once it died.  */
+	syscall.Kill(pid, syscall.SIGKILL)
+	reapOf(t, events, pid)
+	eventually(t, "the dead child out of the tree", func() bool {
+		for _, child := range r.Children() {
+			if pid == child.Pid {
+				return false
+			}
+		}
+		return true
+	})
+
+} /*  End of function  TestTrackChildren.  */
+
+func TestDetectOrphans(t *testing.T) {
+	r := startTestReaper(t, Config{DetectOrphans: true})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	/*  Not launched by us, as good as an orphan.  */
+	if event := reapOf(t, events, spawn(t, "exit 0")); !event.Orphan {
+		t.Errorf("child started behind the reaper's back not tagged orphan")
+	}
+
+	pid, exited, err := r.launch(ChildSpec{Path: "/bin/sh", Args: []string{"sh", "-c", "exit 0"}})
+	if err != nil {
+		t.Fatalf("failed to launch child: %v", err)
+	}
+	select {
+	case event := <-exited:
+		if event.Orphan {
+			t.Errorf("launched child %d tagged orphan", pid)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for child %d to be reaped", pid)
+	}
+
+} /*  End of function  TestDetectOrphans.  */
@@ -0,0 +1,158 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+const (
+	defaultAuditMaxSize    = 10 << 20
+	defaultAuditMaxBackups = 3
+)
+
+// auditFile Audit file (see Config.AuditFile) as a sink connection,
+// rotated whenever the next event would take it past its maximum size.
+// Only ever written to by its sink's goroutine.
+type auditFile struct {
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// Open (or create) the audit file at path for appending.
+func openAudit(path string, maxSize int64, backups int) func() (sinkConn, error) {
+	if maxSize <= 0 {
+		maxSize = defaultAuditMaxSize
+	}
+	if backups <= 0 {
+		backups = defaultAuditMaxBackups
+	}
+
+	return func() (sinkConn, error) {
+		audit := &auditFile{path: path, maxSize: maxSize, backups: backups}
+		if err := audit.open(); err != nil {
+			return nil, err
+		}
+
+		return audit, nil
+	}
+
+} /*  End of function  openAudit.  */
+
+// Open the audit file, picking up its current size.
+func (a *auditFile) open() error {
+	file, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	a.file, a.size = file, info.Size()
+	return nil
+
+} /*  End of method  auditFile.open.  */
+
+// Rotate the audit file: path.N-1 becomes path.N (the oldest one falls
+// off the end), ... and path itself path.1. Then start afresh.
+func (a *auditFile) rotate() error {
+	if err := a.file.Close(); err != nil {
+		return err
+	}
+
+	for idx := a.backups - 1; idx > 0; idx-- {
+		os.Rename(fmt.Sprintf("%s.%d", a.path, idx), fmt.Sprintf("%s.%d", a.path, idx+1))
+	}
+	if err := os.Rename(a.path, a.path+".1"); err != nil {
+		return err
+	}
+
+	return a.open()
+
+} /*  End of method  auditFile.rotate.  */
+
+// Write Implement io.Writer, rotating first if need be. An event is never
+// split across files.
+func (a *auditFile) Write(data []byte) (int, error) {
+	if a.size > 0 && a.size+int64(len(data)) > a.maxSize {
+		if err := a.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := a.file.Write(data)
+	a.size += int64(n)
+	return n, err
+
+} /*  End of [exported] method  auditFile.Write.  */
+
+// Close Implement io.Closer.
+func (a *auditFile) Close() error {
+	return a.file.Close()
+
+} /*  End of [exported] method  auditFile.Close.  */
+
+// SetWriteDeadline Part of sinkConn, no deadlines for a plain file.
+func (a *auditFile) SetWriteDeadline(t time.Time) error {
+	return nil
+
+} /*  End of [exported] method  auditFile.SetWriteDeadline.  */
+
+// Render a reap event as a line of logfmt, the same fields as for JSON
+// (see eventRecord) less the empty ones.
+func logfmtEvent(event ReapEvent) ([]byte, error) {
+	record := recordOf(event)
+
+	keyvals := []interface{}{
+		"seq", record.Seq,
+		"type", record.Type,
+		"pid", record.Pid,
+		"time", record.Time.Format(time.RFC3339Nano),
+	}
+
+	add := func(key string, value interface{}, ok bool) {
+		if ok {
+			keyvals = append(keyvals, key, value)
+		}
+	}
+
+	add("outcome", record.Outcome, "" != record.Outcome)
+	if record.ExitCode != nil {
+		keyvals = append(keyvals, "exit_code", *record.ExitCode)
+	}
+	add("signal", record.Signal, "" != record.Signal)
+	add("core_dumped", true, record.CoreDumped)
+	keyvals = append(keyvals, "raw_status", record.RawStatus)
+	add("orphan", true, record.Orphan)
+	add("self_signaled", true, record.SelfSignaled)
+	add("unexpected", true, record.Unexpected)
+	add("lifetime", record.Lifetime, "" != record.Lifetime)
+	add("user_time", record.UserTime, "" != record.UserTime)
+	add("system_time", record.SystemTime, "" != record.SystemTime)
+	add("max_rss", record.MaxRSS, 0 != record.MaxRSS)
+	add("minor_faults", record.MinorFaults, 0 != record.MinorFaults)
+	add("major_faults", record.MajorFaults, 0 != record.MajorFaults)
+	add("comm", record.Comm, "" != record.Comm)
+	add("cmdline", fmt.Sprintf("%q", record.Cmdline), len(record.Cmdline) > 0)
+
+	var buf bytes.Buffer
+	if err := log.NewLogfmtLogger(&buf).Log(keyvals...); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+
+} /*  End of function  logfmtEvent.  */
@@ -0,0 +1,86 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Read one of the audit files, empty if it isn't there.
+func readAudit(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("can't read %s: %v", path, err)
+	}
+	return string(data)
+
+} /*  End of function  readAudit.  */
+
+func TestAuditRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reaps.log")
+
+	/*  Two lines of 40 bytes to a file, and two backups.  */
+	conn, err := openAudit(path, 100, 2)()
+	if err != nil {
+		t.Fatalf("can't open the audit file: %v", err)
+	}
+	for idx := 1; idx <= 7; idx++ {
+		line := fmt.Sprintf("%-39d\n", idx)
+		if n, err := conn.Write([]byte(line)); err != nil || len(line) != n {
+			t.Fatalf("line %d: wrote %d bytes (%v)", idx, n, err)
+		}
+	}
+	conn.Close()
+
+	for name, expected := range map[string][]string{
+		path:        {"7"},
+		path + ".1": {"5", "6"},
+		path + ".2": {"3", "4"},
+		path + ".3": nil,
+	} {
+		if fields := strings.Fields(readAudit(t, name)); fmt.Sprint(expected) != fmt.Sprint(fields) {
+			t.Errorf("%s holds %v, expected %v", filepath.Base(name), fields, expected)
+		}
+	}
+
+} /*  End of function  TestAuditRotation.  */
+
+func TestAuditReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reaps.log")
+	line := []byte(strings.Repeat("x", 59) + "\n")
+
+	/*  Picks up where it left off - and rotates once that's too much.  */
+	for idx := 0; idx < 2; idx++ {
+		conn, err := openAudit(path, 100, 1)()
+		if err != nil {
+			t.Fatalf("can't open the audit file: %v", err)
+		}
+		if _, err := conn.Write(line); err != nil {
+			t.Fatalf("can't write to the audit file: %v", err)
+		}
+		conn.Close()
+	}
+
+	if current, backup := readAudit(t, path), readAudit(t, path+".1"); len(line) != len(current) || len(line) != len(backup) {
+		t.Errorf("audit file of %d bytes and backup of %d, expected %d each", len(current), len(backup), len(line))
+	}
+
+	/*  An event too big for any file still goes in whole, rotating first.  */
+	conn, err := openAudit(path, 10, 1)()
+	if err != nil {
+		t.Fatalf("can't open the audit file: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write(line); err != nil {
+		t.Fatalf("can't write to the audit file: %v", err)
+	}
+	if current := readAudit(t, path); line[0] != current[0] || len(line) != len(current) {
+		t.Errorf("oversized event split up: %q", current)
+	}
+
+} /*  End of function  TestAuditReopen.  */
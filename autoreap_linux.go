@@ -0,0 +1,44 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le
+// +build linux,!mips,!mipsle,!mips64,!mips64le
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+	"unsafe"
+)
+
+const (
+	sigIgn      = 1   /*  SIG_IGN  */
+	saNoCldWait = 0x2 /*  SA_NOCLDWAIT  */
+)
+
+// The leading fields of the kernel's struct sigaction, with plenty of
+// room to spare for the rest (restorer and mask).
+type sigaction struct {
+	handler uintptr
+	flags   uintptr
+	_       [8]uintptr
+}
+
+// Check if the current SIGCHLD disposition makes the kernel reap
+// children on its own - i.e. if SIGCHLD is ignored or has SA_NOCLDWAIT
+// set.
+//
+// The Go runtime installs its own SIGCHLD handler at startup (without
+// SA_NOCLDWAIT), replacing any inherited SIG_IGN disposition. So this
+// can only be the case if the program called signal.Ignore(SIGCHLD) or
+// some non-Go code (cgo or the host process for a c-shared/c-archive
+// build) changed the disposition after that.
+func autoReaping() (bool, error) {
+	var old sigaction
+
+	_, _, errno := syscall.RawSyscall6(syscall.SYS_RT_SIGACTION, uintptr(syscall.SIGCHLD), 0, uintptr(unsafe.Pointer(&old)), 8, 0, 0)
+	if 0 != errno {
+		return false, errno
+	}
+
+	return sigIgn == old.handler || 0 != old.flags&saNoCldWait, nil
+
+} /*  End of function  autoReaping.  */
@@ -0,0 +1,11 @@
+//go:build !linux || mips || mipsle || mips64 || mips64le
+// +build !linux mips mipsle mips64 mips64le
+
+package reaper
+
+// Reading the SIGCHLD disposition is only wired up for linux (and not
+// for mips, which has its own struct sigaction layout).
+func autoReaping() (bool, error) {
+	return false, ErrUnsupportedPlatform
+
+} /*  End of function  autoReaping.  */
@@ -0,0 +1,96 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+)
+
+// AutoMode How AutoStart went about reaping.
+type AutoMode int
+
+const (
+	// AutoNone Not reaping at all, AutoStart failed.
+	AutoNone AutoMode = iota
+
+	// AutoPid1 Reaping as pid 1 of a container.
+	AutoPid1
+
+	// AutoSubreaper Reaping as a child subreaper, not being pid 1 (see
+	// Config.EnableSubreaper).
+	AutoSubreaper
+)
+
+// AutoResult What AutoStart started: the mode the reaper runs in and the
+// function that stops it (see Embed).
+type AutoResult struct {
+	Mode AutoMode
+	Stop func()
+}
+
+// String Name of the mode.
+func (m AutoMode) String() string {
+	switch m {
+	case AutoNone:
+		return "none"
+	case AutoPid1:
+		return "pid1"
+	case AutoSubreaper:
+		return "subreaper"
+	}
+
+	return "unknown"
+
+} /*  End of [exported] method  AutoMode.String.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// AutoStart Start reaping in the background in whichever way the process
+// can: as pid 1 (of a container, as Embed does) if it is, or else as a
+// child subreaper of its own descendants. Returns once the reaper is up
+// and reaping, with the mode it runs in, or with the error if it failed
+// to start - the mode is then AutoNone. That is ErrHostPid1 for the
+// host's init, and the subreaper error where subreapers aren't supported.
+//
+// As for Embed, the environment gets a say as per ConfigFromEnv (which
+// comes last, REAPER_SUBREAPER included) and with REAPER_DISABLE set
+// the reaper isn't started at all - the mode is AutoNone, with no error.
+// The result's Stop stops the reaper and waits for it to be done, as
+// does cancelling ctx (minus the waiting).
+func AutoStart(ctx context.Context) (AutoResult, error) {
+	if DisabledByEnv() {
+		return AutoResult{Mode: AutoNone, Stop: func() {}}, nil
+	}
+
+	config, err := ConfigFromEnv(Config{Pid: -1, EnableSubreaper: 1 != os.Getpid()})
+	if err != nil {
+		return AutoResult{Mode: AutoNone}, err
+	}
+
+	mode := AutoPid1
+	if config.EnableSubreaper {
+		mode = AutoSubreaper
+	}
+
+	r := New(config)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- r.Run(ctx)
+	}()
+
+	select {
+	case err := <-errs:
+		return AutoResult{Mode: AutoNone}, err
+	case <-r.ready:
+	}
+
+	return AutoResult{Mode: mode, Stop: r.Stop}, nil
+
+} /*  End of [exported] function  AutoStart.  */
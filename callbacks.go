@@ -0,0 +1,187 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CallbackOverflow What to do with a reap event when the callback queue
+// is full (see Config.CallbackWorkers).
+type CallbackOverflow int
+
+const (
+	// OverflowDropNewest Drop the new event, keep the queued ones.
+	OverflowDropNewest CallbackOverflow = iota
+
+	// OverflowDropOldest Drop the oldest queued event to make room.
+	OverflowDropOldest
+
+	// OverflowBlock Hold up the reaper until there is room in the queue
+	// or Config.CallbackBlockTimeout expires - whence the new event is
+	// dropped.
+	OverflowBlock
+)
+
+// String Name of the overflow policy.
+func (o CallbackOverflow) String() string {
+	switch o {
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowBlock:
+		return "block"
+	}
+
+	return "unknown"
+
+} /*  End of [exported] method  CallbackOverflow.String.  */
+
+const (
+	defaultCallbackQueueSize    = 64
+	defaultCallbackBlockTimeout = 1 * time.Second
+)
+
+// Bounded queue of reap events waiting for a callback worker.
+type callbackQueue struct {
+	events   chan ReapEvent
+	overflow CallbackOverflow
+	timeout  time.Duration
+	wg       sync.WaitGroup
+}
+
+// Pick the context to hand to the callbacks: Config.CallbackContext if
+// set, else the lifecycle context passed to Run.
+func (r *Reaper) callbackContext(ctx context.Context) context.Context {
+	if r.config.CallbackContext != nil {
+		return r.config.CallbackContext
+	}
+
+	return ctx
+
+} /*  End of method  Reaper.callbackContext.  */
+
+// Check if there are any callbacks to run for reaped children.
+func (r *Reaper) hasCallbacks() bool {
+	return r.config.ReapCallback != nil || r.config.OnReap != nil
+
+} /*  End of method  Reaper.hasCallbacks.  */
+
+// Run the callbacks for a reaped child, OnReap first.
+func (r *Reaper) runCallbacks(ctx context.Context, event ReapEvent) {
+	if r.config.OnReap != nil {
+		r.config.OnReap(event)
+	}
+	if r.config.ReapCallback != nil {
+		r.config.ReapCallback(ctx, event)
+	}
+
+} /*  End of method  Reaper.runCallbacks.  */
+
+// Start the callback workers, if the callbacks are to run asynchronously.
+func (r *Reaper) startCallbacks(ctx context.Context) {
+	if !r.hasCallbacks() || r.config.CallbackWorkers <= 0 {
+		return
+	}
+
+	size := r.config.CallbackQueueSize
+	if size <= 0 {
+		size = defaultCallbackQueueSize
+	}
+
+	timeout := r.config.CallbackBlockTimeout
+	if timeout <= 0 {
+		timeout = defaultCallbackBlockTimeout
+	}
+
+	q := &callbackQueue{
+		events:   make(chan ReapEvent, size),
+		overflow: r.config.CallbackOverflow,
+		timeout:  timeout,
+	}
+
+	for idx := 0; idx < r.config.CallbackWorkers; idx++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for event := range q.events {
+				r.runCallbacks(ctx, event)
+			}
+		}()
+	}
+
+	r.callbacks = q
+
+} /*  End of method  Reaper.startCallbacks.  */
+
+// Stop the callback workers once they have drained the queue.
+func (r *Reaper) stopCallbacks() {
+	if q := r.callbacks; q != nil {
+		close(q.events)
+		q.wg.Wait()
+		r.callbacks = nil
+	}
+
+} /*  End of method  Reaper.stopCallbacks.  */
+
+// Queue up an event for the callback workers as per the overflow policy.
+// Returns false if an event (the new one or an old one) was dropped.
+func (q *callbackQueue) push(event ReapEvent) bool {
+	select {
+	case q.events <- event:
+		return true
+	default:
+	}
+
+	switch q.overflow {
+	case OverflowDropOldest:
+		for {
+			select {
+			case <-q.events: /*  dropped the oldest.  */
+			default:
+			}
+
+			select {
+			case q.events <- event:
+				return false
+			default:
+				/*  We are the only sender, so there is room now - retry.  */
+			}
+		}
+
+	case OverflowBlock:
+		timer := time.NewTimer(q.timeout)
+		defer timer.Stop()
+
+		select {
+		case q.events <- event:
+			return true
+		case <-timer.C:
+		}
+	}
+
+	return false
+
+} /*  End of method  callbackQueue.push.  */
+
+// Invoke the configured callback (if any) for a reaped child - either
+// right here or via the callback workers.
+func (r *Reaper) callback(ctx context.Context, event ReapEvent) {
+	if !r.hasCallbacks() {
+		return
+	}
+
+	if r.callbacks == nil {
+		r.runCallbacks(ctx, event)
+		return
+	}
+
+	if !r.callbacks.push(event) {
+		r.countStat(func(stats *Stats) { stats.DroppedCallbacks++ })
+	}
+
+} /*  End of method  Reaper.callback.  */
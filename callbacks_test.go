@@ -0,0 +1,98 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallbackQueueOverflow(t *testing.T) {
+	newest := &callbackQueue{events: make(chan ReapEvent, 2), overflow: OverflowDropNewest}
+	oldest := &callbackQueue{events: make(chan ReapEvent, 2), overflow: OverflowDropOldest}
+	block := &callbackQueue{events: make(chan ReapEvent, 2), overflow: OverflowBlock, timeout: 10 * time.Millisecond}
+
+	for _, q := range []*callbackQueue{newest, oldest, block} {
+		for pid := 1; pid <= 2; pid++ {
+			if !q.push(ReapEvent{Pid: pid}) {
+				t.Fatalf("%v: dropped pid %d with room in the queue", q.overflow, pid)
+			}
+		}
+		if q.push(ReapEvent{Pid: 3}) {
+			t.Errorf("%v: nothing dropped with the queue full", q.overflow)
+		}
+	}
+
+	pids := func(q *callbackQueue) []int {
+		close(q.events)
+		var pids []int
+		for event := range q.events {
+			pids = append(pids, event.Pid)
+		}
+		return pids
+	}
+
+	if queued := pids(newest); 2 != len(queued) || 1 != queued[0] || 2 != queued[1] {
+		t.Errorf("drop-newest kept %v, expected [1 2]", queued)
+	}
+	if queued := pids(oldest); 2 != len(queued) || 2 != queued[0] || 3 != queued[1] {
+		t.Errorf("drop-oldest kept %v, expected [2 3]", queued)
+	}
+
+	/*  Blocking gets the event in once there is room.  */
+	q := &callbackQueue{events: make(chan ReapEvent, 1), overflow: OverflowBlock, timeout: 5 * time.Second}
+	q.push(ReapEvent{Pid: 1})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-q.events
+	}()
+	if !q.push(ReapEvent{Pid: 2}) {
+		t.Errorf("block dropped the event, expected it to wait for room")
+	}
+
+} /*  End of function  TestCallbackQueueOverflow.  */
+
+func TestCallbackBudget(t *testing.T) {
+	const children, workers, queue = 20, 2, 4
+
+	var running, peak, handled atomic.Int32
+	release := make(chan struct{})
+	var once sync.Once
+
+	r := startTestReaper(t, Config{
+		CallbackWorkers:   workers,
+		CallbackQueueSize: queue,
+		OnReap: func(event ReapEvent) {
+			now := running.Add(1)
+			for old := peak.Load(); now > old && !peak.CompareAndSwap(old, now); old = peak.Load() {
+			}
+			<-release
+			running.Add(-1)
+			handled.Add(1)
+		},
+	})
+
+	/*  Before the reaper stops, which waits for the callbacks.  */
+	t.Cleanup(func() { once.Do(func() { close(release) }) })
+
+	for idx := 0; idx < children; idx++ {
+		spawn(t, "exit 0")
+	}
+	eventually(t, "the reaps", func() bool { return children == r.Stats().Reaped })
+
+	/*  Two in the works, four queued - the rest dropped.  */
+	expected := uint64(children - workers - queue)
+	eventually(t, "the drops", func() bool { return expected == r.Stats().DroppedCallbacks })
+
+	once.Do(func() { close(release) })
+	eventually(t, "the callbacks", func() bool { return workers+queue == handled.Load() })
+
+	if peak := peak.Load(); peak > workers {
+		t.Errorf("%d callbacks running at once, expected at most %d", peak, workers)
+	}
+
+} /*  End of function  TestCallbackBudget.  */
@@ -0,0 +1,60 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/*  Appended to the path of a cgroup removed while a zombie was in it.  */
+const cgroupDeleted = " (deleted)"
+
+// Parse the contents of a /proc/<pid>/cgroup file, returning the path in
+// the unified (cgroup v2) hierarchy. Only that one is of any use for a
+// zombie: on the v1 hierarchies zombies all show up in the root cgroup,
+// while the unified one keeps reporting the cgroup it died in.
+func parseProcCgroup(data []byte) (string, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if path := strings.TrimPrefix(line, "0::"); path != line {
+			return strings.TrimSuffix(path, cgroupDeleted), nil
+		}
+	}
+
+	return "", errors.New("no unified cgroup hierarchy in proc cgroup")
+
+} /*  End of function  parseProcCgroup.  */
+
+// Read and parse /proc/<pid>/cgroup for the given pid.
+func readProcCgroup(pid int) (string, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+
+	return parseProcCgroup(data)
+
+} /*  End of function  readProcCgroup.  */
+
+// Check if a cgroup path is the given cgroup or one below it.
+func cgroupContains(cgroup, path string) bool {
+	cgroup = strings.TrimSuffix(cgroup, "/")
+	return path == cgroup || strings.HasPrefix(path, cgroup+"/")
+
+} /*  End of function  cgroupContains.  */
+
+// Check if a child is one of ours as per Config.Cgroup - any child is,
+// without it. A child whose cgroup can't be read is not.
+func (r *Reaper) inCgroup(pid int) bool {
+	if "" == r.config.Cgroup {
+		return true
+	}
+
+	path, err := readProcCgroup(pid)
+	return nil == err && cgroupContains(r.config.Cgroup, path)
+
+} /*  End of method  Reaper.inCgroup.  */
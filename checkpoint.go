@@ -0,0 +1,168 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+const (
+	checkpointVersion = 1
+
+	defaultCheckpointInterval = 1 * time.Minute
+)
+
+// checkpoint What's persisted to Config.CheckpointFile.
+type checkpoint struct {
+	Version int       `json:"version"`
+	Time    time.Time `json:"time"`
+	Stats   Stats     `json:"stats"`
+}
+
+// Write the current stats to the checkpoint file. The checkpoint is
+// written to a temporary file that is then renamed over the old one, so
+// a crash mid-write leaves the previous checkpoint intact.
+func (r *Reaper) saveCheckpoint() error {
+	path := r.config.CheckpointFile
+
+	data, err := json.Marshal(checkpoint{
+		Version: checkpointVersion,
+		Time:    time.Now(),
+		Stats:   r.Stats(),
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+
+} /*  End of method  Reaper.saveCheckpoint.  */
+
+// Every checkpoint interval, save the stats to the checkpoint file until
+// the context is done - then save them one last time.
+func (r *Reaper) checkpointTicker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+
+		if err := r.saveCheckpoint(); err != nil {
+			level.Warn(r.config.Logger).Log("msg", "failed to save checkpoint", "file", r.config.CheckpointFile, "err", err)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+
+} /*  End of method  Reaper.checkpointTicker.  */
+
+// Start the checkpoint ticker if configured, returns a function that
+// stops it and waits for the final checkpoint to be written.
+func (r *Reaper) startCheckpoints(ctx context.Context) func() {
+	if r.config.CheckpointFile == "" {
+		return func() {}
+	}
+
+	interval := r.config.CheckpointInterval
+	if interval <= 0 {
+		interval = defaultCheckpointInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		r.checkpointTicker(ctx, interval)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+
+} /*  End of method  Reaper.startCheckpoints.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// LoadCheckpoint Restore the stats saved to Config.CheckpointFile by an
+// earlier run, so the counters carry on where they left off rather than
+// starting from zero. Call it before Run. A missing checkpoint file is
+// not an error (first run). A corrupt or partial one is logged and
+// ignored, the stats then start fresh. Only failures to read the file
+// are returned.
+//
+// The per interval figures (IntervalReaped and Rate) are not restored.
+func (r *Reaper) LoadCheckpoint() error {
+	path := r.config.CheckpointFile
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var saved checkpoint
+	err = json.Unmarshal(data, &saved)
+	if err == nil && checkpointVersion != saved.Version {
+		err = fmt.Errorf("unsupported checkpoint version %d", saved.Version)
+	}
+	if err != nil {
+		level.Warn(r.config.Logger).Log("msg", "ignoring corrupt checkpoint, starting fresh", "file", path, "err", err)
+		return nil
+	}
+
+	saved.Stats.IntervalReaped = 0
+	saved.Stats.Rate = 0
+
+	r.statsMu.Lock()
+	/*  This run's, not the saved one's.  */
+	saved.Stats.Started, saved.Stats.Uptime = r.stats.Started, r.stats.Uptime
+	r.stats = saved.Stats
+	r.statsMu.Unlock()
+
+	level.Info(r.config.Logger).Log("msg", "restored checkpoint", "file", path, "saved", saved.Time, "reaped", saved.Stats.Reaped)
+	return nil
+
+} /*  End of [exported] method  Reaper.LoadCheckpoint.  */
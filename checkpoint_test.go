@@ -0,0 +1,84 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reaper.checkpoint")
+
+	saved := New(Config{CheckpointFile: path, Logger: log.NewNopLogger()})
+	saved.countStat(func(stats *Stats) {
+		stats.Reaped, stats.Exited, stats.Failed, stats.Signaled = 10, 6, 3, 1
+		stats.IntervalReaped, stats.Rate = 4, 0.5
+		stats.LastReap = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		stats.Lifetimes[1] = 7
+		stats.LifetimeSum = 3 * time.Second
+		stats.ExitCodes = map[string]uint64{"0": 6, "1": 3, "signal:9": 1}
+	})
+	if err := saved.saveCheckpoint(); err != nil {
+		t.Fatalf("failed to save checkpoint: %v", err)
+	}
+
+	restored := New(Config{CheckpointFile: path, Logger: log.NewNopLogger()})
+	if err := restored.LoadCheckpoint(); err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+
+	/*  All but the per interval figures, which start over.  */
+	expected := saved.Stats()
+	expected.IntervalReaped, expected.Rate = 0, 0
+	expected.Started, expected.Uptime = restored.Stats().Started, restored.Stats().Uptime
+	if got := restored.Stats(); !reflect.DeepEqual(expected, got) {
+		t.Errorf("restored stats %+v, expected %+v", got, expected)
+	}
+
+	/*  No leftover temporary files.  */
+	if entries, _ := os.ReadDir(filepath.Dir(path)); 1 != len(entries) {
+		t.Errorf("%d files next to the checkpoint, expected none", len(entries)-1)
+	}
+
+} /*  End of function  TestCheckpointRoundTrip.  */
+
+func TestCheckpointCorrupt(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, data := range []string{
+		"",
+		"not json",
+		`{"version": 1, "stats": {"Reaped": 10`,
+		`{"version": 2, "stats": {"Reaped": 10}}`,
+	} {
+		path := filepath.Join(dir, "reaper.checkpoint")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		r := New(Config{CheckpointFile: path, Logger: log.NewNopLogger()})
+		if err := r.LoadCheckpoint(); err != nil {
+			t.Errorf("checkpoint %q: load failed with %v, expected it ignored", data, err)
+		}
+		if reaped := r.Stats().Reaped; 0 != reaped {
+			t.Errorf("checkpoint %q: restored %d reaps, expected a fresh start", data, reaped)
+		}
+	}
+
+	/*  A first run, then one that can't read the checkpoint at all.  */
+	r := New(Config{CheckpointFile: filepath.Join(dir, "missing"), Logger: log.NewNopLogger()})
+	if err := r.LoadCheckpoint(); err != nil {
+		t.Errorf("missing checkpoint: load failed with %v", err)
+	}
+
+	r = New(Config{CheckpointFile: dir, Logger: log.NewNopLogger()})
+	if err := r.LoadCheckpoint(); nil == err {
+		t.Errorf("unreadable checkpoint: loaded without an error")
+	}
+
+} /*  End of function  TestCheckpointCorrupt.  */
@@ -0,0 +1,92 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"sync"
+)
+
+// ReapResult What became of a claimed child (see Claim): its reap event,
+// or the reason there won't be one - ErrAlreadyClaimed or, if the reaper
+// stopped first, ErrNotRunning.
+type ReapResult struct {
+	Event ReapEvent
+	Err   error
+}
+
+// Hand a claimed child's reap event (or the reason there is none) over
+// to the claimant, unless the claim is released first.
+func (r *Reaper) deliver(pid int, exited <-chan ReapEvent, results chan<- ReapResult, released <-chan struct{}) {
+	defer close(results)
+
+	select {
+	case event := <-exited:
+		results <- ReapResult{Event: event}
+	case <-r.done:
+		/*  It may have made it in just before.  */
+		select {
+		case event := <-exited:
+			results <- ReapResult{Event: event}
+		default:
+			results <- ReapResult{Err: ErrNotRunning}
+		}
+	case <-released:
+		r.mu.Lock()
+		if r.waiters[pid] == exited {
+			delete(r.waiters, pid)
+		}
+		r.mu.Unlock()
+	}
+
+} /*  End of method  Reaper.deliver.  */
+
+// Claim a child, see Claim. Caller holds the lock.
+func (r *Reaper) claimLocked(pid int) (<-chan ReapResult, func()) {
+	results := make(chan ReapResult, 1)
+
+	if _, ok := r.waiters[pid]; ok {
+		results <- ReapResult{Err: ErrAlreadyClaimed}
+		close(results)
+		return results, func() {}
+	}
+
+	exited := make(chan ReapEvent, 1)
+	r.waiters[pid] = exited
+	delete(r.claimed, pid)
+
+	released := make(chan struct{})
+	var once sync.Once
+	release := func() { once.Do(func() { close(released) }) }
+
+	go r.deliver(pid, exited, results, released)
+	return results, release
+
+} /*  End of method  Reaper.claimLocked.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Claim Hand a child over to the reaper and have its status delivered:
+// once the reaper reaps the child, its reap event comes in on the
+// returned channel (which is then closed), just like for the children
+// the reaper launched itself - it counts as one of those from here on.
+// Where Register keeps the reaper's hands off a child, Claim is for when
+// you'd rather it did the waiting for you. A registered child is taken
+// over, i.e. unregistered.
+//
+// The returned func releases the claim, closing the channel without a
+// result if there is none yet; the child is then reaped (and reported)
+// like any other. If the reaper stops (or has stopped) before it reaps
+// the child, the result is ErrNotRunning. The child must be one the
+// reaper waits on (see Config.Pid and TargetGroup).
+func (r *Reaper) Claim(pid int) (<-chan ReapResult, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.claimLocked(pid)
+
+} /*  End of [exported] method  Reaper.Claim.  */
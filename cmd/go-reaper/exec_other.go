@@ -0,0 +1,12 @@
+//go:build !(darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris)
+// +build !darwin,!dragonfly,!freebsd,!hurd,!illumos,!ios,!linux,!netbsd,!openbsd,!solaris
+
+package main
+
+//  Prefer #include style directives.
+import reaper "github.com/kakkoyun/go-reaper"
+
+func execCommand(args []string) error {
+	return reaper.ErrUnsupportedPlatform
+
+} /*  End of function  execCommand.  */
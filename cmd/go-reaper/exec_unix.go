@@ -0,0 +1,22 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package main
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// Run the command in our stead, without any reaping.
+func execCommand(args []string) error {
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	return syscall.Exec(path, args, os.Environ())
+
+} /*  End of function  execCommand.  */
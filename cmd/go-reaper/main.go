@@ -0,0 +1,100 @@
+// Command go-reaper A minimal init for containers, built on the reaper:
+// it spawns the given command, reaps any zombies, passes the signals it
+// gets on to the command and exits with the command's exit code. Use it
+// as the container's ENTRYPOINT, in place of tini:
+//
+//	ENTRYPOINT ["/go-reaper", "--"]
+//	CMD ["/usr/local/bin/worker", "--flag"]
+package main
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+/*  Exit codes for our own failures, as per the shell conventions.  */
+const (
+	exitFailure     = 1
+	exitNotRunnable = 126
+	exitNotFound    = 127
+)
+
+// Print the usage message.
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] -- command [args ...]\n\n", os.Args[0])
+	flag.PrintDefaults()
+
+} /*  End of function  usage.  */
+
+// Work out the exit code for a failure to run the command at all.
+func failureCode(err error) int {
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, os.ErrNotExist):
+		return exitNotFound
+	case errors.Is(err, os.ErrPermission):
+		return exitNotRunnable
+	}
+
+	return exitFailure
+
+} /*  End of function  failureCode.  */
+
+func main() {
+	/*  The environment sets the defaults, flags override those.  */
+	config, err := reaper.ConfigFromEnv(reaper.Config{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		os.Exit(exitFailure)
+	}
+
+	flag.BoolVar(&config.Debug, "debug", config.Debug, "log at debug level ($REAPER_DEBUG)")
+	flag.BoolVar(&config.KillProcessGroup, "g", config.KillProcessGroup, "forward signals to the command's process group ($REAPER_KILL_GROUP)")
+	flag.BoolVar(&config.EnableSubreaper, "s", config.EnableSubreaper, "run as a child subreaper, rather than as pid 1 ($REAPER_SUBREAPER)")
+	flag.BoolVar(&config.InitForeground, "fg", config.InitForeground, "run the command in the foreground of our terminal, in a process group of its own")
+	flag.BoolVar(&config.InitSession, "session", config.InitSession, "run the command in a session of its own, with our terminal as its controlling terminal")
+	flag.BoolVar(&config.InitPTY, "pty", config.InitPTY, "run the command on a pty of its own, relaying its stdio")
+	flag.DurationVar(&config.GracePeriod, "grace", config.GracePeriod, "on the way out, give the remaining children this long to exit after a SIGTERM before they get a SIGKILL ($REAPER_GRACE_PERIOD)")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if 0 == len(args) {
+		usage()
+		os.Exit(exitFailure)
+	}
+
+	if reaper.DisabledByEnv() {
+		/*  $REAPER_DISABLE - the command becomes us, as is.  */
+		err := execCommand(args)
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		os.Exit(failureCode(err))
+	}
+
+	config.Pid = -1
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	code, err := reaper.New(config).RunAsInit(context.Background(), cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		if errors.Is(err, reaper.ErrNotPid1) {
+			fmt.Fprintf(os.Stderr, "%s: not running as pid 1, use -s to run as a child subreaper instead\n", os.Args[0])
+		}
+		if code < 0 {
+			code = failureCode(err)
+		}
+	}
+
+	os.Exit(code)
+
+} /*  End of function  main.  */
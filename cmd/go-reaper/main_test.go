@@ -0,0 +1,66 @@
+//go:build linux || freebsd
+// +build linux freebsd
+
+package main
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// Build the binary into a scratch directory, returns its path.
+func build(t *testing.T) string {
+	t.Helper()
+
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("no go tool to build with: %v", err)
+	}
+
+	binary := filepath.Join(t.TempDir(), "go-reaper")
+	if output, err := exec.Command(gobin, "build", "-o", binary, ".").CombinedOutput(); err != nil {
+		t.Fatalf("build failed: %v\n%s", err, output)
+	}
+
+	return binary
+
+} /*  End of function  build.  */
+
+func TestExitCodes(t *testing.T) {
+	binary := build(t)
+
+	/*  Not pid 1 here, so as a child subreaper.  */
+	for _, test := range []struct {
+		script string
+		code   int
+	}{
+		{"exit 0", 0},
+		{"exit 3", 3},
+		{"kill -TERM $$", 143},
+	} {
+		err := exec.Command(binary, "-s", "--", "/bin/sh", "-c", test.script).Run()
+
+		code := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("failed to run %s: %v", binary, err)
+		}
+
+		if test.code != code {
+			t.Errorf("%q: exited %d, expected %d", test.script, code, test.code)
+		}
+	}
+
+	/*  And our own failures, shell style.  */
+	err := exec.Command(binary, "-s", "--", filepath.Join(t.TempDir(), "missing")).Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitNotFound != exitErr.ExitCode() {
+		t.Errorf("missing command: %v, expected exit code %d", err, exitNotFound)
+	}
+
+} /*  End of function  TestExitCodes.  */
@@ -0,0 +1,171 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+// Cmd An exec.Cmd whose child the reaper waits on for you: it is claimed
+// (see Claim) the moment it is started, and Wait gets the child's exit
+// status from the reaper's reap event - so the two never race for it.
+// Use it just like an exec.Cmd, except that ProcessState stays nil and
+// that Wait releases Process: the reap event (and so the pid) is in Event
+// once Wait returns.
+type Cmd struct {
+	*exec.Cmd
+
+	// Event The child's reap event, once Wait returns.
+	Event ReapEvent
+
+	reaper  *Reaper
+	results <-chan ReapResult
+	waited  bool
+}
+
+// ExitError A Cmd's child exited unsuccessfully: with a non-zero exit
+// code or killed by a signal, as per its reap event.
+type ExitError struct {
+	Event ReapEvent
+}
+
+// Error Describe how the child ended, as exec.ExitError would.
+func (e *ExitError) Error() string {
+	if e.Event.Status.Signaled() {
+		return fmt.Sprintf("signal: %v", e.Event.Status.Signal())
+	}
+
+	return fmt.Sprintf("exit status %d", e.Event.Status.ExitStatus())
+
+} /*  End of [exported] method  ExitError.Error.  */
+
+// ExitCode The child's exit code, as per ExitCode.
+func (e *ExitError) ExitCode() int {
+	return e.Event.ExitCode()
+
+} /*  End of [exported] method  ExitError.ExitCode.  */
+
+// Command Like exec.Command, but for a child the reaper leaves alone.
+func (r *Reaper) Command(name string, args ...string) *Cmd {
+	return &Cmd{Cmd: exec.Command(name, args...), reaper: r}
+
+} /*  End of [exported] method  Reaper.Command.  */
+
+// CommandContext Like exec.CommandContext, but for a child the reaper
+// leaves alone.
+func (r *Reaper) CommandContext(ctx context.Context, name string, args ...string) *Cmd {
+	return &Cmd{Cmd: exec.CommandContext(ctx, name, args...), reaper: r}
+
+} /*  End of [exported] method  Reaper.CommandContext.  */
+
+// Start Start the command and claim its pid. The two happen under the
+// reaper's lock, so even a child that dies straight away can't be reaped
+// before it is claimed. The command gets the Config.ParentDeathSignal,
+// unless it asked for one of its own.
+func (c *Cmd) Start() error {
+	r := c.reaper
+
+	if sig := r.config.ParentDeathSignal; 0 != sig {
+		if c.SysProcAttr == nil {
+			c.SysProcAttr = &syscall.SysProcAttr{}
+		}
+		setPdeathsig(c.SysProcAttr, sig, false)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := c.Cmd.Start(); err != nil {
+		return err
+	}
+
+	c.results, _ = r.claimLocked(c.Process.Pid)
+	return nil
+
+} /*  End of [exported] method  Cmd.Start.  */
+
+// Wait Wait for the reaper to reap the command's child and for its I/O
+// to be done, just like exec.Cmd.Wait. Fails with an *ExitError if the
+// child exited unsuccessfully, or with ErrNotRunning if the reaper
+// stopped before reaping it.
+func (c *Cmd) Wait() error {
+	if nil == c.results {
+		/*  Not started, exec.Cmd has the error for that.  */
+		return c.Cmd.Wait()
+	}
+	if c.waited {
+		return errors.New("exec: Wait was already called")
+	}
+	c.waited = true
+
+	result := <-c.results
+
+	/*
+	 *  The reaper did the waiting. Released, the process isn't waited
+	 *  on (or signalled) again - its pid may already be someone else's
+	 *  - and exec.Cmd just gets to finish the I/O and close the pipes.
+	 */
+	c.Process.Release()
+	c.Cmd.Wait()
+
+	if result.Err != nil {
+		return result.Err
+	}
+
+	c.Event = result.Event
+	if !c.Event.Status.Exited() || 0 != c.Event.Status.ExitStatus() {
+		return &ExitError{Event: c.Event}
+	}
+
+	return nil
+
+} /*  End of [exported] method  Cmd.Wait.  */
+
+// Run Start the command and wait for it to exit.
+func (c *Cmd) Run() error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+
+	return c.Wait()
+
+} /*  End of [exported] method  Cmd.Run.  */
+
+// Output Run the command and return its standard output.
+func (c *Cmd) Output() ([]byte, error) {
+	if c.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+
+	var stdout bytes.Buffer
+	c.Stdout = &stdout
+
+	err := c.Run()
+	return stdout.Bytes(), err
+
+} /*  End of [exported] method  Cmd.Output.  */
+
+// CombinedOutput Run the command and return its combined standard output
+// and standard error.
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	if c.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	if c.Stderr != nil {
+		return nil, errors.New("exec: Stderr already set")
+	}
+
+	var output bytes.Buffer
+	c.Stdout = &output
+	c.Stderr = &output
+
+	err := c.Run()
+	return output.Bytes(), err
+
+} /*  End of [exported] method  Cmd.CombinedOutput.  */
@@ -0,0 +1,74 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestCommandExitStatus(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	cmd := r.Command("/bin/sh", "-c", "exit 3")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	pid := cmd.Process.Pid
+	err := cmd.Wait()
+
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || 3 != exitErr.ExitCode() {
+		t.Fatalf("run: %v, expected exit status 3", err)
+	}
+	if pid != cmd.Event.Pid || 3 != cmd.Event.ExitCode() {
+		t.Errorf("reap event %+v, expected pid %d with exit code 3", cmd.Event, pid)
+	}
+
+	if err := cmd.Wait(); nil == err || !strings.Contains(err.Error(), "already called") {
+		t.Errorf("second wait: %v, expected it to fail", err)
+	}
+
+} /*  End of function  TestCommandExitStatus.  */
+
+func TestCommandOutput(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	output, err := r.Command("/bin/sh", "-c", "echo reaped; exit 0").Output()
+	if err != nil || "reaped\n" != string(output) {
+		t.Fatalf("output %q, %v - expected \"reaped\\n\"", output, err)
+	}
+
+	stats := r.Stats()
+	if 1 != stats.Reaped {
+		t.Errorf("reaped %d, expected the reaper to reap the command", stats.Reaped)
+	}
+
+} /*  End of function  TestCommandOutput.  */
+
+func TestCommandReaperStopped(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	cmd := r.Command("/bin/sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	/*  With the reaper gone, it is up to us to reap it.  */
+	pid := cmd.Process.Pid
+	defer func() {
+		syscall.Kill(pid, syscall.SIGKILL)
+		syscall.Wait4(pid, nil, 0, nil)
+	}()
+
+	r.Stop()
+	if err := cmd.Wait(); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("wait: %v, expected ErrNotRunning", err)
+	}
+
+} /*  End of function  TestCommandReaperStopped.  */
@@ -0,0 +1,82 @@
+// Package compat Drop-in replacement for the original ramr/go-reaper API,
+// to ease migrating to github.com/kakkoyun/go-reaper. Change the import
+// path and the code compiles and behaves as before:
+//
+//	import reaper "github.com/kakkoyun/go-reaper/compat"
+//
+//	go reaper.Reap()
+//
+// The mapping to the context based API is:
+//
+//	compat.Reap()        ->  reaper.Reap(context.Background())
+//	compat.Start(config) ->  reaper.Start(context.Background(), config)
+//
+// with the Pid, Options, DisablePid1Check and Debug fields carried over
+// as is. Just like the original, reaping happens in the background and
+// can't be stopped.
+//
+// Deprecated: This package only exists for migration and won't grow any
+// new features, use github.com/kakkoyun/go-reaper directly - it lets you
+// stop the reaper via the context and reports errors back to you.
+package compat
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"fmt"
+	"os"
+
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+// Config The original reaper configuration.
+type Config struct {
+	Pid              int
+	Options          int
+	DisablePid1Check bool
+	Debug            bool
+}
+
+// Convert to the configuration of the context based implementation.
+func (c Config) config() reaper.Config {
+	return reaper.Config{
+		Pid:              c.Pid,
+		Options:          c.Options,
+		DisablePid1Check: c.DisablePid1Check,
+		Debug:            c.Debug,
+	}
+
+} /*  End of method  Config.config.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Reap Normal entry point for the reaper code. Start reaping children in the
+// background inside a goroutine - only if we are running as pid 1.
+func Reap() {
+	Start(Config{
+		Pid:              -1,
+		Options:          0,
+		DisablePid1Check: false,
+	})
+
+} /*  End of [exported] function  Reap.  */
+
+// Start Entry point for invoking the reaper code with a specific configuration.
+// The config allows you to bypass the pid 1 checks, so handle with care.
+// The child processes are reaped in the background inside a goroutine.
+func Start(config Config) {
+	go func() {
+		/*  No one to return errors to, so just say so.  */
+		err := reaper.Start(context.Background(), config.config())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, " - Grim reaper disabled: %v\n", err)
+		}
+	}()
+
+} /*  End of [exported] function  Start.  */
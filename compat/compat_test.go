@@ -0,0 +1,52 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package compat
+
+//  Prefer #include style directives.
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+func TestConfig(t *testing.T) {
+	config := Config{Pid: 42, Options: syscall.WUNTRACED, DisablePid1Check: true, Debug: true}
+
+	expected := reaper.Config{Pid: 42, Options: syscall.WUNTRACED, DisablePid1Check: true, Debug: true}
+	if got := config.config(); expected.Pid != got.Pid || expected.Options != got.Options ||
+		expected.DisablePid1Check != got.DisablePid1Check || expected.Debug != got.Debug {
+		t.Errorf("converted %+v to %+v, expected %+v", config, got, expected)
+	}
+
+} /*  End of function  TestConfig.  */
+
+func TestStartReaps(t *testing.T) {
+	Start(Config{Pid: -1, DisablePid1Check: true})
+
+	/*  Started in the background, so keep at it until it is up.  */
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		cmd := exec.Command("/bin/sh", "-c", "exit 0")
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("failed to start a child: %v", err)
+		}
+		pid := cmd.Process.Pid
+
+		/*  A zombie can still be signalled, a reaped child can't.  */
+		for wait := time.Now().Add(100 * time.Millisecond); time.Now().Before(wait); {
+			if syscall.ESRCH == syscall.Kill(pid, 0) {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("child %d not reaped", pid)
+		}
+	}
+
+} /*  End of function  TestStartReaps.  */
@@ -0,0 +1,100 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"encoding/json"
+	"os"
+)
+
+// MarshalJSON Render the serializable subset of the config, e.g. for audit
+// logs. Fields that can't be serialized (logger, callbacks, contexts,
+// channels) are rendered as booleans telling whether or not they are set,
+// as is the webhook URL (it may well carry a token), enums by name,
+// signals and durations as strings.
+func (c Config) MarshalJSON() ([]byte, error) {
+	/*
+	 *  The alias type drops this method (no recursion) and the fields
+	 *  below shadow the non-serializable ones of the same name.
+	 */
+	type config Config
+
+	return json.Marshal(struct {
+		config
+		Logger               bool
+		Slog                 bool
+		FallbackFormat       string
+		ReapCallback         bool
+		OnReap               bool
+		StatusChannel        bool
+		CallbackContext      bool
+		CallbackOverflow     string
+		CallbackBlockTimeout string
+		OnContinued          bool
+		OnStopped            bool
+		OnDrop               bool
+		OnAdopted            bool
+		StatsInterval        string
+		OnStats              bool
+		CheckpointInterval   string
+		SweepOnSignals       []string
+		ForwardSignals       []string
+		ParentDeathSignal    string
+		GracePeriod          string
+		SweepInterval        string
+		EventWriter          bool
+		WebhookURL           bool
+	}{
+		config:               config(c),
+		Logger:               c.Logger != nil,
+		Slog:                 c.Slog != nil,
+		FallbackFormat:       c.FallbackFormat.String(),
+		ReapCallback:         c.ReapCallback != nil,
+		OnReap:               c.OnReap != nil,
+		StatusChannel:        c.StatusChannel != nil,
+		CallbackContext:      c.CallbackContext != nil,
+		CallbackOverflow:     c.CallbackOverflow.String(),
+		CallbackBlockTimeout: c.CallbackBlockTimeout.String(),
+		OnContinued:          c.OnContinued != nil,
+		OnStopped:            c.OnStopped != nil,
+		OnDrop:               c.OnDrop != nil,
+		OnAdopted:            c.OnAdopted != nil,
+		StatsInterval:        c.StatsInterval.String(),
+		OnStats:              c.OnStats != nil,
+		CheckpointInterval:   c.CheckpointInterval.String(),
+		SweepOnSignals:       signalNames(c.SweepOnSignals),
+		ForwardSignals:       signalNames(c.ForwardSignals),
+		ParentDeathSignal:    signalName(c.ParentDeathSignal),
+		GracePeriod:          c.GracePeriod.String(),
+		SweepInterval:        c.SweepInterval.String(),
+		EventWriter:          c.EventWriter != nil,
+		WebhookURL:           "" != c.WebhookURL,
+	})
+
+} /*  End of [exported] method  Config.MarshalJSON.  */
+
+// Name of the given signal, if any.
+func signalName(sig Signal) string {
+	if 0 == sig {
+		return ""
+	}
+
+	return sig.String()
+
+} /*  End of function  signalName.  */
+
+// Names of the given signals.
+func signalNames(sigs []os.Signal) []string {
+	if sigs == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(sigs))
+	for _, sig := range sigs {
+		names = append(names, sig.String())
+	}
+
+	return names
+
+} /*  End of function  signalNames.  */
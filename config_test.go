@@ -0,0 +1,94 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+func TestConfigMarshalJSON(t *testing.T) {
+	config := Config{
+		Pid:                  -1,
+		Logger:               log.NewNopLogger(),
+		Slog:                 slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ReapCallback:         func(ctx context.Context, event ReapEvent) {},
+		OnReap:               func(event ReapEvent) {},
+		StatusChannel:        make(chan ReapEvent),
+		CallbackContext:      context.Background(),
+		CallbackOverflow:     OverflowDropOldest,
+		CallbackBlockTimeout: 3 * time.Second,
+		OnContinued:          func(pid int) {},
+		OnStopped:            func(pid int) {},
+		OnDrop:               func(sig os.Signal) {},
+		OnAdopted:            func(event ReapEvent) {},
+		OnStats:              func(stats Stats) {},
+		StatsInterval:        time.Minute,
+		SweepOnSignals:       []os.Signal{syscall.SIGUSR1},
+		ParentDeathSignal:    syscall.SIGTERM,
+		EventWriter:          io.Discard,
+		WebhookURL:           "https://hooks.example.com/?token=secret",
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("the webhook URL made it into %s", data)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	/*  Every field that can't be rendered is there, as being set.  */
+	kind := reflect.TypeOf(config)
+	for idx := 0; idx < kind.NumField(); idx++ {
+		field := kind.Field(idx)
+		switch field.Type.Kind() {
+		case reflect.Func, reflect.Chan, reflect.Interface, reflect.Ptr:
+			if set, ok := fields[field.Name].(bool); !ok || !set {
+				t.Errorf("%s rendered as %v, expected true", field.Name, fields[field.Name])
+			}
+		}
+	}
+
+	for name, expected := range map[string]interface{}{
+		"Pid":                  -1.0,
+		"WebhookURL":           true,
+		"CallbackOverflow":     "drop-oldest",
+		"CallbackBlockTimeout": "3s",
+		"StatsInterval":        "1m0s",
+		"ParentDeathSignal":    "terminated",
+		"FallbackFormat":       "text",
+	} {
+		if expected != fields[name] {
+			t.Errorf("%s rendered as %v, expected %v", name, fields[name], expected)
+		}
+	}
+
+	if sigs, ok := fields["SweepOnSignals"].([]interface{}); !ok || 1 != len(sigs) {
+		t.Errorf("SweepOnSignals rendered as %v", fields["SweepOnSignals"])
+	}
+
+	/*  And unset, they render as false.  */
+	data, _ = json.Marshal(Config{})
+	if !strings.Contains(string(data), `"OnReap":false`) || !strings.Contains(string(data), `"WebhookURL":false`) {
+		t.Errorf("empty config rendered as %s", data)
+	}
+
+} /*  End of function  TestConfigMarshalJSON.  */
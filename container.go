@@ -0,0 +1,69 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Inode of the initial (host) pid namespace, PROC_PID_INIT_INO.
+const initPidNamespace = "pid:[4026531836]"
+
+// Root of the filesystem to look for container marker files in,
+// overridable so that detection can be pointed at a fake tree.
+var containerRoot = "/"
+
+// Files container runtimes drop into the container's filesystem.
+var containerMarkers = []string{
+	".dockerenv",            /*  docker  */
+	"run/.containerenv",     /*  podman  */
+	"run/systemd/container", /*  systemd-nspawn and friends  */
+}
+
+// Bits of the cgroup paths that give away a container.
+var containerCgroupHints = []string{
+	"docker", "kubepods", "containerd", "crio", "libpod", "lxc",
+}
+
+// Best-effort check if we are running inside a container. Returns the
+// indicator found - which is empty if we seem to be on the host. When in
+// doubt (e.g. no /proc to look at) this errs on the side of a container,
+// so that it never stops a legit container init from reaping.
+func inContainer() (bool, string) {
+	for _, marker := range containerMarkers {
+		if _, err := os.Stat(filepath.Join(containerRoot, marker)); err == nil {
+			return true, marker
+		}
+	}
+
+	if runtime := os.Getenv("container"); runtime != "" {
+		return true, "container=" + runtime
+	}
+
+	ns, err := os.Readlink(filepath.Join(procRoot, "self", "ns", "pid"))
+	if err != nil {
+		return true, "no pid namespace info"
+	}
+	if initPidNamespace != ns {
+		return true, "pid namespace " + ns
+	}
+
+	/*
+	 *  Host pid namespace - but we could still be in a container
+	 *  sharing it (docker --pid=host), check the cgroups for that.
+	 */
+	data, err := os.ReadFile(filepath.Join(procRoot, "self", "cgroup"))
+	if err == nil {
+		for _, hint := range containerCgroupHints {
+			if strings.Contains(string(data), hint) {
+				return true, "cgroup " + hint
+			}
+		}
+	}
+
+	return false, ""
+
+} /*  End of function  inContainer.  */
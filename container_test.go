@@ -0,0 +1,58 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInContainer(t *testing.T) {
+	saved := containerRoot
+	t.Cleanup(func() { containerRoot = saved })
+
+	for _, test := range []struct {
+		name    string
+		marker  string
+		env     string
+		ns      string
+		cgroup  string
+		inside  bool
+		because string
+	}{
+		{"docker marker", ".dockerenv", "", initPidNamespace, "", true, ".dockerenv"},
+		{"podman marker", "run/.containerenv", "", initPidNamespace, "", true, "run/.containerenv"},
+		{"container env", "", "lxc", initPidNamespace, "", true, "container=lxc"},
+		{"no proc", "", "", "", "", true, "no pid namespace info"},
+		{"pid namespace", "", "", "pid:[4026532201]", "", true, "pid namespace pid:[4026532201]"},
+		{"host pid namespace", "", "", initPidNamespace, "0::/kubepods/besteffort/pod42\n", true, "cgroup kubepods"},
+		{"host", "", "", initPidNamespace, "0::/init.scope\n", false, ""},
+	} {
+		proc := newFakeProc(t)
+		containerRoot = t.TempDir()
+		if "" != test.marker {
+			path := filepath.Join(containerRoot, test.marker)
+			os.MkdirAll(filepath.Dir(path), 0o755)
+			if err := os.WriteFile(path, nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		t.Setenv("container", test.env)
+
+		if "" != test.ns {
+			os.MkdirAll(filepath.Join(proc.root, "self", "ns"), 0o755)
+			if err := os.Symlink(test.ns, filepath.Join(proc.root, "self", "ns", "pid")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if "" != test.cgroup {
+			proc.write("self/cgroup", test.cgroup)
+		}
+
+		inside, because := inContainer()
+		if test.inside != inside || test.because != because {
+			t.Errorf("%s: in container %v (%q), expected %v (%q)", test.name, inside, because, test.inside, test.because)
+		}
+	}
+
+} /*  End of function  TestInContainer.  */
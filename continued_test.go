@@ -0,0 +1,70 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+// Receive a pid, failing the test if none comes within 5 seconds.
+func receivePid(t *testing.T, what string, pids <-chan int) int {
+	t.Helper()
+
+	select {
+	case pid := <-pids:
+		return pid
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+
+	return 0
+
+} /*  End of function  receivePid.  */
+
+func TestStoppedAndContinued(t *testing.T) {
+	continued := make(chan int, 1)
+	stopped := make(chan int, 1)
+
+	r := startTestReaper(t, Config{
+		Options:     syscall.WUNTRACED | wCONTINUED,
+		OnContinued: func(pid int) { continued <- pid },
+		OnStopped:   func(pid int) { stopped <- pid },
+	})
+	events, _ := r.Subscribe(8)
+
+	/*
+	 *  Stops itself, then exits once continued - but not right away,
+	 *  else the exit may well be all there is to see by the time the
+	 *  reaper waits on it.
+	 */
+	pid := spawn(t, "kill -STOP $$; sleep 0.2; exit 4")
+
+	if got := receivePid(t, "the stop", stopped); pid != got {
+		t.Fatalf("stopped pid %d, expected %d", got, pid)
+	}
+	if err := syscall.Kill(pid, syscall.SIGCONT); err != nil {
+		t.Fatalf("failed to continue pid %d: %v", pid, err)
+	}
+	if got := receivePid(t, "the continue", continued); pid != got {
+		t.Fatalf("continued pid %d, expected %d", got, pid)
+	}
+	eventually(t, "the reap", func() bool { return 1 == r.Stats().Reaped })
+
+	var types []EventType
+	for len(types) < 3 {
+		types = append(types, (<-events).Type)
+	}
+	if EventStopped != types[0] || EventContinued != types[1] || EventReaped != types[2] {
+		t.Errorf("got events %v, expected stopped, continued and reaped", types)
+	}
+
+	stats := r.Stats()
+	if 1 != stats.Stopped || 1 != stats.Continued || 1 != stats.Reaped || 1 != stats.ExitCodes["4"] {
+		t.Errorf("stats %+v, expected just the one reap", stats)
+	}
+
+} /*  End of function  TestStoppedAndContinued.  */
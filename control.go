@@ -0,0 +1,168 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+/*  How long a control connection may sit idle before it is dropped.  */
+const controlIdleTimeout = 30 * time.Second
+
+// What the control socket answers: a result or an error.
+type controlReply struct {
+	OK     bool        `json:"ok"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Ask the reap loop to sweep right away, returns the number reaped.
+func (r *Reaper) sweepNow() (int, error) {
+	reaped := make(chan int, 1)
+
+	select {
+	case r.sweeps <- reaped:
+	case <-r.done:
+		return 0, ErrNotRunning
+	}
+
+	select {
+	case n := <-reaped:
+		return n, nil
+	case <-r.done:
+		return 0, ErrNotRunning
+	}
+
+} /*  End of method  Reaper.sweepNow.  */
+
+// Run a control command.
+func (r *Reaper) control(line string) (interface{}, error) {
+	args := strings.Fields(line)
+	if 0 == len(args) {
+		return nil, errors.New("no command")
+	}
+
+	switch cmd := args[0]; {
+	case "stats" == cmd && 1 == len(args):
+		return r.Stats(), nil
+
+	case "children" == cmd && 1 == len(args):
+		return r.Children(), nil
+
+	case "reap-now" == cmd && 1 == len(args):
+		n, err := r.sweepNow()
+		return map[string]int{"reaped": n}, err
+
+	case "set-log-level" == cmd && 2 == len(args):
+		if nil == r.logLevel {
+			return nil, errors.New("log level is up to the configured logger")
+		}
+		if err := r.logLevel.set(args[1]); err != nil {
+			return nil, err
+		}
+		level.Info(r.config.Logger).Log("msg", "log level changed", "level", args[1])
+		return map[string]string{"level": args[1]}, nil
+	}
+
+	return nil, fmt.Errorf("unknown command %q, want stats, children, reap-now or set-log-level <level>", line)
+
+} /*  End of method  Reaper.control.  */
+
+// Serve a control connection: one command per line, each answered with
+// a line of JSON.
+func (r *Reaper) serveControl(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		conn.SetReadDeadline(time.Now().Add(controlIdleTimeout))
+		if !scanner.Scan() {
+			return
+		}
+
+		result, err := r.control(scanner.Text())
+		reply := controlReply{OK: nil == err, Result: result}
+		if err != nil {
+			reply.Error = err.Error()
+		}
+		if encoder.Encode(reply) != nil {
+			return
+		}
+	}
+
+} /*  End of method  Reaper.serveControl.  */
+
+// Start the control server on the unix socket at path, which is only
+// accessible to our own user. A stale socket left behind is replaced.
+// Returns a func that stops the server (dropping any connections) and
+// removes the socket.
+func (r *Reaper) startControlServer(path string) (func(), error) {
+	if info, err := os.Lstat(path); err == nil && 0 != info.Mode()&os.ModeSocket {
+		os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	var (
+		mu    sync.Mutex
+		conns = make(map[net.Conn]struct{})
+		wg    sync.WaitGroup
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			conns[conn] = struct{}{}
+			mu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.serveControl(conn)
+
+				mu.Lock()
+				delete(conns, conn)
+				mu.Unlock()
+			}()
+		}
+	}()
+
+	return func() {
+		listener.Close()
+
+		mu.Lock()
+		for conn := range conns {
+			conn.Close()
+		}
+		mu.Unlock()
+
+		wg.Wait()
+	}, nil
+
+} /*  End of method  Reaper.startControlServer.  */
@@ -0,0 +1,162 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+func TestControlSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+	startTestReaper(t, Config{ControlSocket: path})
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("no control socket: %v", err)
+	}
+	if mode := info.Mode().Perm(); 0600 != mode {
+		t.Errorf("control socket mode %o, expected 600", mode)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("cannot connect to the control socket: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	replies := bufio.NewScanner(conn)
+	ask := func(line string) controlReply {
+		t.Helper()
+
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+		if !replies.Scan() {
+			t.Fatalf("%q: no reply: %v", line, replies.Err())
+		}
+
+		var reply controlReply
+		if err := json.Unmarshal(replies.Bytes(), &reply); err != nil {
+			t.Fatalf("%q: bad reply %s: %v", line, replies.Text(), err)
+		}
+		return reply
+	}
+
+	keeper := spawn(t, "exec sleep 10")
+	defer killChild(keeper)
+
+	for _, test := range []struct {
+		line   string
+		ok     bool
+		result string
+	}{
+		{"stats", true, `"Reaped":`},
+		{"children", true, `"pid":`},
+		{"reap-now", true, `"reaped":`},
+		{"set-log-level debug", false, "up to the configured logger"},
+		{"", false, "no command"},
+		{"stats now", false, "unknown command"},
+		{"reboot", false, "unknown command"},
+	} {
+		reply := ask(test.line)
+		if test.ok != reply.OK {
+			t.Errorf("%q: ok %v (%s), expected %v", test.line, reply.OK, reply.Error, test.ok)
+			continue
+		}
+
+		got := reply.Error
+		if reply.OK {
+			result, _ := json.Marshal(reply.Result)
+			got = string(result)
+		}
+		if !strings.Contains(got, test.result) {
+			t.Errorf("%q: replied %s, expected %s", test.line, got, test.result)
+		}
+	}
+
+	/*  Still running, reap-now did not wait on it.  */
+	if err := syscall.Kill(keeper, 0); err != nil {
+		t.Errorf("child %d gone after the control commands: %v", keeper, err)
+	}
+
+} /*  End of function  TestControlSocket.  */
+
+func TestControlSetLogLevel(t *testing.T) {
+	/*  Our own logger, so that the level is ours to change.  */
+	r := New(Config{Pid: -1})
+
+	if _, err := r.control("set-log-level loud"); nil == err {
+		t.Errorf("set an unknown log level")
+	}
+	if _, err := r.control("set-log-level warn"); err != nil {
+		t.Errorf("cannot set the log level: %v", err)
+	}
+	if _, err := r.control("set-log-level"); nil == err {
+		t.Errorf("set a log level without one")
+	}
+
+} /*  End of function  TestControlSetLogLevel.  */
+
+func TestControlStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+
+	/*  Left behind by a reaper that died.  */
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("cannot create a socket: %v", err)
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	listener.Close()
+
+	r := New(Config{Pid: -1, Logger: log.NewNopLogger()})
+	stop, err := r.startControlServer(path)
+	if err != nil {
+		t.Fatalf("stale socket not replaced: %v", err)
+	}
+	stop()
+
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("socket left behind after stopping: %v", err)
+	}
+
+} /*  End of function  TestControlStaleSocket.  */
+
+func TestReapNowWhileWaiting(t *testing.T) {
+	/*  None ready, then one that died without a SIGCHLD to tell.  */
+	calls := fakeWait4(t, waitResult{pid: 0}, waitResult{pid: 42, wstatus: 3 << 8}, waitResult{pid: 0})
+	r := New(Config{Pid: -1, Logger: log.NewNopLogger()})
+
+	swept := make(chan int, 1)
+	go func() {
+		swept <- r.sweepWith(context.Background(), context.Background(), nil, 0)
+	}()
+
+	n, err := r.sweepNow()
+	if err != nil || 1 != n {
+		t.Errorf("reap-now reaped %d (%v), expected 1", n, err)
+	}
+
+	select {
+	case total := <-swept:
+		if 1 != total || 4 != calls() {
+			t.Errorf("sweep reaped %d in %d waits, expected 1 in 4", total, calls())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("sweep still waiting once the children are gone")
+	}
+
+} /*  End of function  TestReapNowWhileWaiting.  */
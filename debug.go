@@ -0,0 +1,138 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// What the debug page shows.
+type debugState struct {
+	Running      bool          `json:"running"`
+	Reason       string        `json:"shutdown_reason"`
+	LastActivity time.Time     `json:"last_activity"`
+	Config       Config        `json:"config"`
+	ConfigText   string        `json:"-"`
+	Stats        Stats         `json:"stats"`
+	Zombies      *int          `json:"zombies,omitempty"`
+	Failures     []eventRecord `json:"recent_failures"`
+	Events       []eventRecord `json:"recent_events"`
+	Children     []Child       `json:"children"`
+}
+
+var debugPage = template.Must(template.New("reaper").Parse(`<!DOCTYPE html>
+<html>
+<head><title>grim reaper</title></head>
+<body>
+<h1>grim reaper</h1>
+<p>Running: {{.Running}}{{if not .Running}} (last shutdown: {{.Reason}}){{end}}, last activity {{.LastActivity.Format "2006-01-02T15:04:05Z07:00"}}</p>
+
+<h2>Stats</h2>
+<table>
+{{if not .Stats.Started.IsZero}}<tr><td>started</td><td>{{.Stats.Started.Format "2006-01-02T15:04:05Z07:00"}} (up {{.Stats.Uptime}})</td></tr>
+{{end}}<tr><td>reaped</td><td>{{.Stats.Reaped}}</td></tr>
+<tr><td>exited</td><td>{{.Stats.Exited}}</td></tr>
+<tr><td>failed</td><td>{{.Stats.Failed}}</td></tr>
+<tr><td>signaled</td><td>{{.Stats.Signaled}}</td></tr>
+<tr><td>core dumped</td><td>{{.Stats.CoreDumped}}</td></tr>
+<tr><td>last reap</td><td>{{if not .Stats.LastReap.IsZero}}{{.Stats.LastReap.Format "2006-01-02T15:04:05Z07:00"}}{{else}}-{{end}}</td></tr>
+<tr><td>sweep reaped</td><td>{{.Stats.SweepReaped}}</td></tr>
+{{if .Stats.Unreported}}<tr><td>unreported (other cgroups)</td><td>{{.Stats.Unreported}}</td></tr>
+{{end}}<tr><td>wait errors</td><td>{{.Stats.WaitErrors}}</td></tr>
+<tr><td>dropped signals</td><td>{{.Stats.DroppedSignals}}</td></tr>
+<tr><td>dropped events</td><td>{{.Stats.DroppedEvents}}</td></tr>
+<tr><td>dropped callbacks</td><td>{{.Stats.DroppedCallbacks}}</td></tr>
+<tr><td>dropped sink events</td><td>{{.Stats.DroppedSinkEvents}}</td></tr>
+{{if .Config.WebhookURL}}<tr><td>dropped webhooks</td><td>{{.Stats.DroppedWebhooks}}</td></tr>
+{{end}}<tr><td>suppressed logs</td><td>{{.Stats.SuppressedLogs}}</td></tr>
+{{if .Zombies}}<tr><td>zombies</td><td>{{.Zombies}}</td></tr>
+{{end}}</table>
+
+<h2>Recent failures</h2>
+{{if .Failures}}{{template "events" .Failures}}{{else}}<p>None (see Config.FailureHistorySize).</p>{{end}}
+
+<h2>Recent reaps</h2>
+{{if .Events}}{{template "events" .Events}}{{else}}<p>None (see Config.EventHistorySize).</p>{{end}}
+
+<h2>Children</h2>
+{{if .Children}}<table>
+<tr><th>pid</th><th>parent</th><th>comm</th><th>started</th></tr>
+{{range .Children}}<tr><td>{{.Pid}}</td><td>{{.Parent}}</td><td>{{.Comm}}</td><td>{{if not .Started.IsZero}}{{.Started.Format "2006-01-02T15:04:05Z07:00"}}{{end}}</td></tr>
+{{end}}</table>{{else}}<p>None.</p>{{end}}
+
+<h2>Config</h2>
+<pre>{{.ConfigText}}</pre>
+</body>
+</html>
+{{define "events"}}<table>
+<tr><th>seq</th><th>time</th><th>pid</th><th>outcome</th><th>exit code</th><th>signal</th><th>unexpected</th></tr>
+{{range .}}<tr><td>{{.Seq}}</td><td>{{.Time.Format "2006-01-02T15:04:05Z07:00"}}</td><td>{{.Pid}}</td><td>{{.Outcome}}</td><td>{{if .ExitCode}}{{.ExitCode}}{{end}}</td><td>{{.Signal}}</td><td>{{.Unexpected}}</td></tr>
+{{end}}</table>{{end}}`))
+
+// Gather up what the debug page shows.
+func (r *Reaper) debugState() debugState {
+	r.statsMu.Lock()
+	running, active := r.running, r.lastActivity
+	r.statsMu.Unlock()
+
+	state := debugState{
+		Running:      running,
+		Reason:       r.ShutdownReason().String(),
+		LastActivity: active,
+		Config:       r.config,
+		Stats:        r.Stats(),
+		Failures:     []eventRecord{},
+		Events:       []eventRecord{},
+		Children:     r.Children(),
+	}
+
+	if data, err := json.MarshalIndent(r.config, "", "  "); err == nil {
+		state.ConfigText = string(data)
+	}
+
+	/*  Left out where there is no /proc to count them in.  */
+	if zombies, err := CountZombies(); err == nil {
+		state.Zombies = &zombies
+	}
+
+	for _, event := range r.RecentFailures() {
+		state.Failures = append(state.Failures, recordOf(event))
+	}
+	for _, event := range r.RecentEvents() {
+		state.Events = append(state.Events, recordOf(event))
+	}
+
+	return state
+
+} /*  End of method  Reaper.debugState.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Handler Returns an http.Handler serving a debug page for the reaper:
+// whether it is running, its stats (including the drop counts), the
+// recent failures and reaps (see RecentFailures and RecentEvents), our
+// process tree (see Children) and its config. As JSON with
+// ?format=json. Mount it on a mux of your own, e.g. at /debug/reaper.
+func (r *Reaper) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		state := r.debugState()
+
+		if "json" == req.URL.Query().Get("format") {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(state)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		debugPage.Execute(w, state)
+	})
+
+} /*  End of [exported] method  Reaper.Handler.  */
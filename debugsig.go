@@ -0,0 +1,86 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+
+	"github.com/go-kit/log/level"
+)
+
+// Log a dump of our state: the stats, the children and the goroutines.
+func (r *Reaper) dumpState() {
+	keyvals := []interface{}{"msg", "state dump", "goroutines", runtime.NumGoroutine()}
+
+	if data, err := json.Marshal(r.Stats()); err == nil {
+		keyvals = append(keyvals, "stats", string(data))
+	}
+	if data, err := json.Marshal(r.Children()); err == nil {
+		keyvals = append(keyvals, "children", string(data))
+	}
+
+	var stacks bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&stacks, 1); err == nil {
+		keyvals = append(keyvals, "stacks", stacks.String())
+	}
+
+	level.Info(r.config.Logger).Log(keyvals...)
+
+} /*  End of method  Reaper.dumpState.  */
+
+// Toggle debug logging, if the logger is ours to toggle.
+func (r *Reaper) toggleDebug() {
+	if nil == r.logLevel {
+		level.Warn(r.config.Logger).Log("msg", "can't toggle debug logging, log level is up to the configured logger")
+		return
+	}
+
+	name := r.logLevel.toggleDebug()
+	level.Info(r.config.Logger).Log("msg", "log level changed", "level", name)
+
+} /*  End of method  Reaper.toggleDebug.  */
+
+// Start handling the debug signals (see Config.DebugSignals). Returns a
+// func that stops handling them again.
+func (r *Reaper) startDebugSignals() func() {
+	if nil == sigUSR1 {
+		return func() {}
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, sigUSR1, sigUSR2)
+
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-quit:
+				return
+			case sig := <-sigs:
+				if sigUSR1 == sig {
+					r.dumpState()
+				} else {
+					r.toggleDebug()
+				}
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigs)
+		close(quit)
+		wg.Wait()
+	}
+
+} /*  End of method  Reaper.startDebugSignals.  */
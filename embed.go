@@ -0,0 +1,56 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+)
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Embed Start reaping in the background, for a service that runs as pid 1
+// in its container and just wants its zombies gone. Returns once the
+// reaper is up and reaping, or with the error if it failed to start
+// (e.g. ErrNotPid1). Logging is at warn level, or debug with
+// REAPER_DEBUG set: there's not a line to be seen unless something is
+// off.
+//
+// The environment gets a say as per ConfigFromEnv, with REAPER_DISABLE
+// set Embed doesn't start the reaper at all (and stop does nothing).
+//
+// The returned stop function stops the reaper and waits for it to be
+// done, as does cancelling ctx (minus the waiting).
+func Embed(ctx context.Context) (stop func(), err error) {
+	if DisabledByEnv() {
+		return func() {}, nil
+	}
+
+	config, err := ConfigFromEnv(Config{Pid: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	r := New(config)
+	if nil != r.logLevel && !config.Debug {
+		r.logLevel.set("warn")
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- r.Run(ctx)
+	}()
+
+	select {
+	case err := <-errs:
+		return nil, err
+	case <-r.ready:
+	}
+
+	return r.Stop, nil
+
+} /*  End of [exported] function  Embed.  */
@@ -0,0 +1,41 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbedDisabled(t *testing.T) {
+	t.Setenv(EnvDisable, "true")
+
+	stop, err := Embed(context.Background())
+	if err != nil {
+		t.Fatalf("embed with %s set: %v", EnvDisable, err)
+	}
+	stop()
+
+} /*  End of function  TestEmbedDisabled.  */
+
+func TestEmbedNotPid1(t *testing.T) {
+	if 1 == os.Getpid() {
+		t.Skip("running as pid 1")
+	}
+
+	if _, err := Embed(context.Background()); !errors.Is(err, ErrNotPid1) {
+		t.Fatalf("embed: %v, expected ErrNotPid1", err)
+	}
+
+} /*  End of function  TestEmbedNotPid1.  */
+
+func TestEmbedBadEnv(t *testing.T) {
+	t.Setenv(EnvGracePeriod, "forever")
+
+	if _, err := Embed(context.Background()); nil == err || !strings.Contains(err.Error(), EnvGracePeriod) {
+		t.Fatalf("embed with a bad %s: %v, expected it to fail", EnvGracePeriod, err)
+	}
+
+} /*  End of function  TestEmbedBadEnv.  */
@@ -0,0 +1,34 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"syscall"
+	"testing"
+)
+
+func TestEmbedReaps(t *testing.T) {
+	/*  Short of being pid 1, reap as a subreaper.  */
+	t.Setenv(EnvSubreaper, "true")
+
+	stop, err := Embed(context.Background())
+	if err != nil {
+		t.Skipf("can't embed a subreaper here: %v", err)
+	}
+
+	/*  Gone for good once reaped, a zombie could still be signalled.  */
+	pid := spawn(t, "exit 0")
+	eventually(t, "the child to be reaped", func() bool {
+		return syscall.ESRCH == syscall.Kill(pid, 0)
+	})
+
+	stop()
+	stop()
+
+	/*  Stopped for real, another reaper gets to run.  */
+	startTestReaper(t, Config{})
+
+} /*  End of function  TestEmbedReaps.  */
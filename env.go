@@ -0,0 +1,83 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables to configure the reaper with (see ConfigFromEnv).
+const (
+	EnvDisable     = "REAPER_DISABLE"
+	EnvDebug       = "REAPER_DEBUG"
+	EnvGracePeriod = "REAPER_GRACE_PERIOD"
+	EnvKillGroup   = "REAPER_KILL_GROUP"
+	EnvSubreaper   = "REAPER_SUBREAPER"
+)
+
+// Overlay a boolean environment variable, if set, onto a config field.
+func envBool(name string, field *bool) error {
+	value := os.Getenv(name)
+	if "" == value {
+		return nil
+	}
+
+	on, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("grim reaper: bad %s: %w", name, err)
+	}
+
+	*field = on
+	return nil
+
+} /*  End of function  envBool.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// ConfigFromEnv Overlay whatever of the REAPER_* environment variables
+// are set onto config, so that operators can tune the reaper per
+// deployment without code changes: REAPER_DEBUG, REAPER_KILL_GROUP and
+// REAPER_SUBREAPER (booleans, as per strconv.ParseBool) set Debug,
+// KillProcessGroup and EnableSubreaper, REAPER_GRACE_PERIOD (a duration,
+// as per time.ParseDuration) GracePeriod. Fails on malformed values.
+// REAPER_DISABLE is up to the caller, see DisabledByEnv.
+func ConfigFromEnv(config Config) (Config, error) {
+	for name, field := range map[string]*bool{
+		EnvDebug:     &config.Debug,
+		EnvKillGroup: &config.KillProcessGroup,
+		EnvSubreaper: &config.EnableSubreaper,
+	} {
+		if err := envBool(name, field); err != nil {
+			return config, err
+		}
+	}
+
+	if value := os.Getenv(EnvGracePeriod); "" != value {
+		grace, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("grim reaper: bad %s: %w", EnvGracePeriod, err)
+		}
+		config.GracePeriod = grace
+	}
+
+	return config, nil
+
+} /*  End of [exported] function  ConfigFromEnv.  */
+
+// DisabledByEnv Check if REAPER_DISABLE is set to a true value, i.e. the
+// reaper is not to be run. A malformed value doesn't disable it.
+func DisabledByEnv() bool {
+	var disabled bool
+	envBool(EnvDisable, &disabled)
+
+	return disabled
+
+} /*  End of [exported] function  DisabledByEnv.  */
@@ -0,0 +1,85 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotPid1 The reaper is not running as pid 1 and the pid 1 check
+	// isn't disabled (see Config.DisablePid1Check).
+	ErrNotPid1 = errors.New("grim reaper disabled, pid not 1")
+
+	// ErrHostPid1 The reaper is pid 1 on the host rather than in a
+	// container (see Config.AllowHostReaping).
+	ErrHostPid1 = errors.New("grim reaper disabled, pid 1 but not in a container - set AllowHostReaping to override")
+
+	// ErrUnsupportedPlatform Reaping (or some feature of it) isn't
+	// available on this platform - there's no wait4(2) on windows.
+	ErrUnsupportedPlatform = errors.New("grim reaper: not supported on this platform")
+
+	// ErrAlreadyRunning Run was called on a reaper that has been run
+	// already - a reaper is good for a single Run, create a new one with
+	// New to reap again. Or another reaper is running in this process:
+	// only one may wait on the same children at a time (observers that
+	// set Config.AssumeAutoReap aside), it is released once its Run
+	// returns. Reapers of distinct process groups (see TargetGroup) may
+	// run side by side.
+	ErrAlreadyRunning = errors.New("grim reaper: already running")
+
+	// ErrAlreadyClaimed The child is spoken for already: claimed (see
+	// Claim) or launched by the reaper itself.
+	ErrAlreadyClaimed = errors.New("grim reaper: child already claimed")
+
+	// ErrNotRunning The reaper's reap loop isn't running (see Healthy).
+	ErrNotRunning = errors.New("grim reaper: not running")
+
+	// ErrNoSignalHandler The reaper's SIGCHLD handler isn't running, or
+	// SIGCHLD got ignored behind its back (see Healthy).
+	ErrNoSignalHandler = errors.New("grim reaper: SIGCHLD handler not running")
+
+	// ErrNotReaped The child didn't get reaped even after it was sent a
+	// SIGKILL (see Terminate).
+	ErrNotReaped = errors.New("grim reaper: child not reaped")
+
+	// ErrTooManyRestarts A supervised child was restarted MaxRestarts
+	// times in a row and exited yet again (see ChildSpec).
+	ErrTooManyRestarts = errors.New("grim reaper: too many restarts")
+)
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// IsCleanShutdown Check if an error returned by Run (or Start) just means
+// that the reaper was asked to stop: no error at all (Stop, MaxReaps or
+// Config.CancelIsClean) or its context being cancelled or timing out.
+func IsCleanShutdown(err error) bool {
+	return nil == err || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+
+} /*  End of [exported] function  IsCleanShutdown.  */
+
+// IsFatal Check if an error returned by Run (or Start) is down to the
+// configuration or the environment the reaper runs in (ErrInvalidConfig,
+// ErrNotPid1, ErrHostPid1, ErrUnsupportedPlatform). Running the reaper
+// again as is will fail the same way.
+func IsFatal(err error) bool {
+	return errors.Is(err, ErrInvalidConfig) || errors.Is(err, ErrNotPid1) ||
+		errors.Is(err, ErrHostPid1) || errors.Is(err, ErrUnsupportedPlatform)
+
+} /*  End of [exported] function  IsFatal.  */
+
+// IsRetryable Check if it is worth running the reaper again after Run (or
+// Start) returned err: any error that is neither a clean shutdown nor
+// fatal, e.g. the health server failing to listen or custom context
+// cancellation causes (see Config.CancelIsClean).
+func IsRetryable(err error) bool {
+	return !IsCleanShutdown(err) && !IsFatal(err)
+
+} /*  End of [exported] function  IsRetryable.  */
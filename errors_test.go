@@ -0,0 +1,58 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+func TestErrorClasses(t *testing.T) {
+	for _, test := range []struct {
+		err       error
+		clean     bool
+		fatal     bool
+		retryable bool
+	}{
+		{nil, true, false, false},
+		{context.Canceled, true, false, false},
+		{context.DeadlineExceeded, true, false, false},
+		{fmt.Errorf("grim reaper: %w", context.Canceled), true, false, false},
+		{ErrNotPid1, false, true, false},
+		{ErrHostPid1, false, true, false},
+		{fmt.Errorf("grim reaper: %w", ErrUnsupportedPlatform), false, true, false},
+		{ErrInvalidConfig, false, true, false},
+		{errors.Join(&ConfigError{Field: "MaxReaps", Reason: "negative (-1)"}), false, true, false},
+		{errors.New("shutting down for maintenance"), false, false, true},
+		{ErrNotReaped, false, false, true},
+	} {
+		if clean := IsCleanShutdown(test.err); test.clean != clean {
+			t.Errorf("%v: clean shutdown %v, expected %v", test.err, clean, test.clean)
+		}
+		if fatal := IsFatal(test.err); test.fatal != fatal {
+			t.Errorf("%v: fatal %v, expected %v", test.err, fatal, test.fatal)
+		}
+		if retryable := IsRetryable(test.err); test.retryable != retryable {
+			t.Errorf("%v: retryable %v, expected %v", test.err, retryable, test.retryable)
+		}
+	}
+
+	/*  As is a config that doesn't make sense.  */
+	err := New(Config{Pid: -1, MaxReaps: -1, Logger: log.NewNopLogger()}).Run(context.Background())
+	if !IsFatal(err) {
+		t.Errorf("run with an invalid config failed with %v, expected a fatal error", err)
+	}
+
+	/*  What Run fails with when it isn't pid 1 is as fatal as it gets.  */
+	if 1 != os.Getpid() {
+		err := New(Config{Pid: -1, Logger: log.NewNopLogger()}).Run(context.Background())
+		if !IsFatal(err) {
+			t.Errorf("run not as pid 1 failed with %v, expected a fatal error", err)
+		}
+	}
+
+} /*  End of function  TestErrorClasses.  */
@@ -0,0 +1,196 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// How long the stragglers get to die (and be reaped) once SIGKILLed.
+var killReapTimeout = 5 * time.Second
+
+// Find our children that are still alive (those we may wait on, see
+// Children), along with all of their descendants if so asked. Without
+// /proc, that's just the children the reaper launched itself.
+func (r *Reaper) remaining(descendants bool) []int {
+	tree, err := r.children()
+	if err != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		pids := make([]int, 0, len(r.waiters))
+		for pid := range r.waiters {
+			pids = append(pids, pid)
+		}
+		return pids
+	}
+
+	self := os.Getpid()
+	var pids []int
+	for _, child := range tree {
+		if descendants || self == child.Parent {
+			pids = append(pids, child.Pid)
+		}
+	}
+
+	return pids
+
+} /*  End of method  Reaper.remaining.  */
+
+// Filter out the given children that are gone (reaped) by now.
+func (r *Reaper) alive(pids []int) []int {
+	current := make(map[int]struct{})
+	for _, pid := range r.remaining(false) {
+		current[pid] = struct{}{}
+	}
+
+	alive := pids[:0]
+	for _, pid := range pids {
+		if _, ok := current[pid]; ok {
+			alive = append(alive, pid)
+		}
+	}
+
+	return alive
+
+} /*  End of method  Reaper.alive.  */
+
+// Send a signal to the given children, returns the ones still around.
+func (r *Reaper) signalAll(pids []int, sig Signal) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alive := pids[:0]
+	for _, pid := range pids {
+		if err := r.signalLocked(pid, sig, false); err != nil {
+			level.Debug(r.config.Logger).Log("msg", "failed to signal child", "pid", pid, "signal", sig, "err", err)
+			continue
+		}
+		alive = append(alive, pid)
+	}
+
+	return alive
+
+} /*  End of method  Reaper.signalAll.  */
+
+// Count the children that are still alive, leaving out the registered ones
+// - their owners wait on them, not us.
+func (r *Reaper) unclaimed(pids []int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, pid := range pids {
+		if _, ok := r.claimed[pid]; !ok {
+			n++
+		}
+	}
+
+	return n
+
+} /*  End of method  Reaper.unclaimed.  */
+
+// Keep reaping until all our (unclaimed) children are gone or the timeout
+// expires, whichever comes first. Returns false on timeout.
+func (r *Reaper) reapFor(ctx, cbctx context.Context, wakeups <-chan os.Signal, timeout time.Duration) bool {
+	expired := time.After(timeout)
+
+	for {
+		r.sweep(ctx, cbctx, nil)
+		if 0 == r.unclaimed(r.remaining(false)) || r.limitReached() {
+			return true
+		}
+
+		select {
+		case <-wakeups:
+		case pid := <-r.exits:
+			r.reapPidfd(cbctx, pid)
+		case <-expired:
+			return false
+		}
+	}
+
+} /*  End of method  Reaper.reapFor.  */
+
+// The grace period each of the given children gets: its own (see
+// ChildSpec.GracePeriod), if it has one, or the reaper's.
+func (r *Reaper) gracesOf(pids []int, grace time.Duration) map[int]time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	graces := make(map[int]time.Duration, len(pids))
+	for _, pid := range pids {
+		graces[pid] = grace
+		if own, ok := r.graces[pid]; ok {
+			graces[pid] = own
+		}
+	}
+
+	return graces
+
+} /*  End of method  Reaper.gracesOf.  */
+
+// Shut down the remaining children: SIGTERM them all, give them the grace
+// period to exit and SIGKILL the stragglers (and whatever processes they
+// have left), reaping them as they go. A child with a grace period of
+// its own is SIGKILLed on its own once that is up, the rest go together
+// once the longest one is.
+func (r *Reaper) escalate(ctx, cbctx context.Context, wakeups <-chan os.Signal) {
+	logger := r.config.Logger
+
+	pids := r.signalAll(r.remaining(false), sigTERM)
+	if 0 == len(pids) {
+		return
+	}
+
+	graces := r.gracesOf(pids, r.config.GracePeriod)
+	grace := time.Duration(0)
+	for _, own := range graces {
+		if own > grace {
+			grace = own
+		}
+	}
+
+	level.Info(logger).Log("msg", "terminating remaining children", "count", len(pids), "grace_period", grace)
+	started := time.Now()
+	for {
+		/*  The next grace period to be up.  */
+		next := grace
+		for _, own := range graces {
+			if own < next {
+				next = own
+			}
+		}
+
+		if r.reapFor(ctx, cbctx, wakeups, time.Until(started.Add(next))) {
+			return
+		}
+		if next == grace {
+			break
+		}
+
+		var due []int
+		for pid, own := range graces {
+			if own == next {
+				due = append(due, pid)
+				delete(graces, pid)
+			}
+		}
+		if due = r.signalAll(r.alive(due), sigKILL); len(due) > 0 {
+			level.Warn(logger).Log("msg", "killing children past their grace period", "count", len(due), "grace_period", next)
+		}
+	}
+
+	/*  Their own children too, or they'd be orphaned to us - and linger.  */
+	pids = r.signalAll(r.remaining(true), sigKILL)
+	level.Warn(logger).Log("msg", "killing remaining children", "count", len(pids))
+	if !r.reapFor(ctx, cbctx, wakeups, killReapTimeout) {
+		level.Error(logger).Log("msg", "children still around after SIGKILL", "count", r.unclaimed(r.remaining(false)))
+	}
+
+} /*  End of method  Reaper.escalate.  */
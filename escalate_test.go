@@ -0,0 +1,145 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// The script for a child that ignores SIGTERM.
+const stubbornScript = "trap '' TERM; exec sleep 10"
+
+// Wait for a stubborn child to ignore SIGTERM: SIG_IGN survives the exec,
+// so once it is a sleep, the trap is set.
+func awaitTrap(t *testing.T, pid int) {
+	t.Helper()
+
+	eventually(t, "the trap", func() bool {
+		comm, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/comm")
+		return nil == err && "sleep" == strings.TrimSpace(string(comm))
+	})
+
+} /*  End of function  awaitTrap.  */
+
+// Start a child that ignores SIGTERM, once it does.
+func spawnStubborn(t *testing.T) int {
+	t.Helper()
+
+	pid := spawn(t, stubbornScript)
+	awaitTrap(t, pid)
+	return pid
+
+} /*  End of function  spawnStubborn.  */
+
+func TestGracePeriodEscalation(t *testing.T) {
+	if _, err := os.Stat("/proc/self/comm"); err != nil {
+		t.Skipf("no /proc to look for children in: %v", err)
+	}
+
+	const grace = 300 * time.Millisecond
+	r := startTestReaper(t, Config{GracePeriod: grace})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	stubborn := spawnStubborn(t)
+	obedient := spawn(t, "exec sleep 10")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	if err := r.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if took := time.Since(started); took < grace {
+		t.Errorf("shut down in %v, before the %v grace period was up", took, grace)
+	}
+
+	/*  The SIGTERM did it for one, the SIGKILL for the other.  */
+	expected := map[int]syscall.Signal{obedient: syscall.SIGTERM, stubborn: syscall.SIGKILL}
+	for len(expected) > 0 {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("children %v not reaped on the way out", expected)
+			}
+			sig, waited := expected[event.Pid]
+			if !waited || EventReaped != event.Type {
+				continue
+			}
+			if !event.Status.Signaled() || sig != event.Status.Signal() {
+				t.Errorf("child %d reaped with status %#x, expected killed by %v", event.Pid, uint32(event.Status), sig)
+			}
+			delete(expected, event.Pid)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for children %v to be reaped", expected)
+		}
+	}
+
+} /*  End of function  TestGracePeriodEscalation.  */
+
+// Have the stragglers no longer than timeout to be reaped once SIGKILLed.
+func fastKillReaps(t *testing.T, timeout time.Duration) {
+	saved := killReapTimeout
+	killReapTimeout = timeout
+	t.Cleanup(func() { killReapTimeout = saved })
+
+} /*  End of function  fastKillReaps.  */
+
+func TestChildGracePeriod(t *testing.T) {
+	if _, err := os.Stat("/proc/self/comm"); err != nil {
+		t.Skipf("no /proc to look for children in: %v", err)
+	}
+
+	const grace, own = 200 * time.Millisecond, 800 * time.Millisecond
+	r := startTestReaper(t, Config{GracePeriod: grace})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	stubborn := spawnStubborn(t)
+	launched, _, err := r.launch(ChildSpec{
+		Path:        "/bin/sh",
+		Args:        []string{"sh", "-c", stubbornScript},
+		GracePeriod: own,
+	})
+	if err != nil {
+		t.Fatalf("failed to launch child: %v", err)
+	}
+	awaitTrap(t, launched)
+
+	started := time.Now()
+	go r.Stop()
+
+	/*  Each one SIGKILLed once its own grace period is up.  */
+	expected := map[int]time.Duration{stubborn: grace, launched: own}
+	for len(expected) > 0 {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("children %v not reaped on the way out", expected)
+			}
+			due, waited := expected[event.Pid]
+			if !waited || EventReaped != event.Type {
+				continue
+			}
+			if took := event.Time.Sub(started); took < due || took > due+own/2 {
+				t.Errorf("child %d reaped after %v, expected %v or so", event.Pid, took, due)
+			}
+			if !event.Status.Signaled() || syscall.SIGKILL != event.Status.Signal() {
+				t.Errorf("child %d reaped with status %#x, expected killed", event.Pid, uint32(event.Status))
+			}
+			delete(expected, event.Pid)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for children %v to be reaped", expected)
+		}
+	}
+
+} /*  End of function  TestChildGracePeriod.  */
@@ -0,0 +1,404 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"sync"
+	"time"
+)
+
+// EventType What happened to a child.
+type EventType int
+
+const (
+	// EventReaped The child died and was reaped.
+	EventReaped EventType = iota
+
+	// EventContinued The child was resumed by SIGCONT (needs WCONTINUED
+	// in Config.Options). It is still alive.
+	EventContinued
+
+	// EventStopped The child was stopped by a signal (needs WUNTRACED in
+	// Config.Options), see Status.StopSignal. It is still alive.
+	EventStopped
+
+	// EventAdopted An orphan was re-parented to us (needs
+	// Config.DetectAdoptions), see Comm and Cmdline. It is alive.
+	EventAdopted
+)
+
+// String Name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventReaped:
+		return "reaped"
+	case EventContinued:
+		return "continued"
+	case EventStopped:
+		return "stopped"
+	case EventAdopted:
+		return "adopted"
+	}
+
+	return "unknown"
+
+} /*  End of [exported] method  EventType.String.  */
+
+// Outcome How a reaped child ended.
+type Outcome int
+
+const (
+	// OutcomeExited Exited cleanly, with exit code 0.
+	OutcomeExited Outcome = iota
+
+	// OutcomeFailed Exited with a non-zero exit code.
+	OutcomeFailed
+
+	// OutcomeSignaled Killed by a signal.
+	OutcomeSignaled
+
+	// OutcomeCoreDumped Killed by a signal, dumping core.
+	OutcomeCoreDumped
+)
+
+// String Name of the outcome.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeExited:
+		return "exited"
+	case OutcomeFailed:
+		return "failed"
+	case OutcomeSignaled:
+		return "signaled"
+	case OutcomeCoreDumped:
+		return "core-dumped"
+	}
+
+	return "unknown"
+
+} /*  End of [exported] method  Outcome.String.  */
+
+// Classify a wait status.
+func outcomeOf(wstatus WaitStatus) Outcome {
+	switch {
+	case wstatus.Signaled() && wstatus.CoreDump():
+		return OutcomeCoreDumped
+	case wstatus.Signaled():
+		return OutcomeSignaled
+	case 0 != wstatus.ExitStatus():
+		return OutcomeFailed
+	}
+
+	return OutcomeExited
+
+} /*  End of function  outcomeOf.  */
+
+// Decode a wait status into log keyvals: the outcome, the exit code or the
+// signal (and whether it dumped core) and the raw status as is.
+func statusKeyvals(wstatus WaitStatus) []interface{} {
+	keyvals := []interface{}{"outcome", outcomeOf(wstatus).String()}
+
+	if wstatus.Signaled() {
+		keyvals = append(keyvals, "signal", wstatus.Signal().String(), "core_dumped", wstatus.CoreDump())
+	} else {
+		keyvals = append(keyvals, "exit_code", wstatus.ExitStatus())
+	}
+
+	return append(keyvals, "raw_status", rawStatus(wstatus))
+
+} /*  End of function  statusKeyvals.  */
+
+// ReapEvent Details about a reaped child process - or some other state
+// change of a child, as per Type.
+//
+// Orphan is only filled in when Config.DetectOrphans is set: it marks
+// a child that the reaper neither launched itself (see Supervise) nor
+// had registered with it (see Register) - most likely an orphan that got
+// re-parented to us. The kernel rewrites the parent pid on re-parenting,
+// so there is no telling such orphans from children started behind the
+// reaper's back (e.g. via os/exec), which get tagged as well. For actual
+// re-parenting, see Config.DetectAdoptions and EventAdopted.
+//
+// Seq is assigned by the reaper in reap order, starting at 1 and without
+// gaps, before the event is handed out to anyone. A gap in the sequence
+// numbers seen by a subscriber means that it missed events.
+//
+// SelfSignaled marks a child killed by a signal that the reaper itself
+// sent it (see Signal and KillAll), as opposed to one sent by someone
+// else - the kernel (OOM killer), an operator etc.
+//
+// Unexpected marks a child that exited with a non-zero exit code it was
+// not expected to exit with, or got killed by someone else's signal (see
+// Config.ExpectedExitCodes and ExpectExitCodes).
+//
+// Lifetime is how long a child the reaper launched itself (see Supervise)
+// lived, from launch to reap. For any other child it is worked out from
+// the child's start time in /proc with Config.TrackLifetimes set, and is
+// zero otherwise.
+//
+// Comm and Cmdline are the name and command line of an adopted orphan,
+// as per /proc, only filled in for EventAdopted events.
+type ReapEvent struct {
+	Type   EventType
+	Seq    uint64
+	Pid    int
+	Status WaitStatus
+	Time   time.Time
+	Orphan bool
+
+	SelfSignaled bool
+	Unexpected   bool
+	Lifetime     time.Duration
+	Usage        Usage
+
+	Comm    string
+	Cmdline []string
+}
+
+// Usage Resources used by a reaped child (and those of its own children it
+// waited for), as the kernel reports them at reap time. MaxRSS is in
+// bytes. All zero where the platform doesn't report them.
+type Usage struct {
+	UserTime    time.Duration
+	SystemTime  time.Duration
+	MaxRSS      int64
+	MinorFaults int64
+	MajorFaults int64
+}
+
+// Outcome How the reaped child ended.
+func (e ReapEvent) Outcome() Outcome {
+	return outcomeOf(e.Status)
+
+} /*  End of [exported] method  ReapEvent.Outcome.  */
+
+// ExitCode The exit code to pass on for the reaped child, as per ExitCode.
+func (e ReapEvent) ExitCode() int {
+	return ExitCode(e.Status)
+
+} /*  End of [exported] method  ReapEvent.ExitCode.  */
+
+// ExitCode The exit code a shell would report for a child with the given
+// wait status: its exit status or, if a signal killed it, 128 plus the
+// signal number (143 for SIGTERM). Exiting with this keeps the exit
+// semantics container orchestrators expect of an init.
+func ExitCode(wstatus WaitStatus) int {
+	if wstatus.Signaled() {
+		return 128 + int(wstatus.Signal())
+	}
+
+	return wstatus.ExitStatus()
+
+} /*  End of [exported] function  ExitCode.  */
+
+// Work out (and forget) how long a reaped child lived, false if we didn't
+// launch it. Caller holds the lock.
+func (r *Reaper) lifetimeLocked(pid int, reaped time.Time) (time.Duration, bool) {
+	launched, ok := r.launched[pid]
+	if !ok {
+		return 0, false
+	}
+
+	delete(r.launched, pid)
+	return reaped.Sub(launched), true
+
+} /*  End of method  Reaper.lifetimeLocked.  */
+
+// Check if a child about to be reaped is to be tagged as an orphan
+// (Config.DetectOrphans): one we did not launch or have registered.
+func (r *Reaper) orphaned(pid int) bool {
+	return r.config.DetectOrphans && !r.familiar(pid)
+
+} /*  End of method  Reaper.orphaned.  */
+
+// Hand a reap event to whoever is waiting for that pid (see WaitFor).
+// Caller holds the lock.
+func (r *Reaper) notifyWatchersLocked(event ReapEvent) {
+	for _, reaped := range r.watchers[event.Pid] {
+		reaped <- event /*  buffered, never blocks.  */
+		close(reaped)
+	}
+	delete(r.watchers, event.Pid)
+
+} /*  End of method  Reaper.notifyWatchersLocked.  */
+
+// Start waiting for the reap of the child with the given pid, see
+// WaitFor.
+func (r *Reaper) watch(pid int) chan ReapEvent {
+	reaped := make(chan ReapEvent, 1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		close(reaped)
+		return reaped
+	default:
+	}
+
+	r.watchers[pid] = append(r.watchers[pid], reaped)
+	return reaped
+
+} /*  End of method  Reaper.watch.  */
+
+// Stop waiting for the reap of a child, closing the channel unless the
+// reap (or the reaper stopping) got there first.
+func (r *Reaper) unwatch(pid int, reaped chan ReapEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	watchers := r.watchers[pid]
+	for idx, watcher := range watchers {
+		if watcher != reaped {
+			continue
+		}
+
+		close(reaped)
+		watchers = append(watchers[:idx], watchers[idx+1:]...)
+		if 0 == len(watchers) {
+			delete(r.watchers, pid)
+		} else {
+			r.watchers[pid] = watchers
+		}
+		return
+	}
+
+} /*  End of method  Reaper.unwatch.  */
+
+// Publish a reap event to all the subscribers and Config.StatusChannel.
+// A subscriber that isn't keeping up misses the event rather than
+// holding up the reaper.
+//
+// Caller holds the lock, which is what serializes the events: they are
+// published one at a time in reap order, each to every subscriber in
+// turn, so each subscriber sees them in reap order too.
+func (r *Reaper) publish(event ReapEvent) {
+	for _, events := range r.subscribers {
+		select {
+		case events <- event: /*  published it.  */
+		default:
+			/*
+			 *  Subscriber channel full - drop it to the floor, just
+			 *  for this subscriber. The others still get it.
+			 */
+			r.countStat(func(stats *Stats) { stats.DroppedEvents++ })
+		}
+	}
+
+	if status := r.config.StatusChannel; status != nil {
+		select {
+		case status <- event:
+		default:
+			r.countStat(func(stats *Stats) { stats.DroppedEvents++ })
+		}
+	}
+
+} /*  End of method  Reaper.publish.  */
+
+// Remove a subscriber and close its channel, unless that already
+// happened (unsubscribed before or the reaper is done).
+func (r *Reaper) unsubscribe(events chan ReapEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, ch := range r.subscribers {
+		if ch == events {
+			r.subscribers = append(r.subscribers[:idx], r.subscribers[idx+1:]...)
+			close(events)
+			return
+		}
+	}
+
+} /*  End of method  Reaper.unsubscribe.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Subscribe Get a channel on which the reaper publishes an event for every
+// child it reaps. The channel has room for size events, if the subscriber
+// doesn't keep up events are dropped rather than blocking the reaper.
+// The channel is closed when the returned unsubscribe function is called
+// or when the reaper's Run returns.
+//
+// Ordering: every subscriber receives the events in the order the reaper
+// reaped the children. Drops are per subscriber - an event that doesn't
+// fit into one subscriber's channel is skipped for that subscriber only,
+// so a subscriber sees a subsequence (in order) of all the reap events,
+// and a slow subscriber has no effect on what the others see. Dropped
+// events are counted in Stats.DroppedEvents.
+func (r *Reaper) Subscribe(size int) (<-chan ReapEvent, func()) {
+	if size < 0 {
+		size = 0
+	}
+
+	events := make(chan ReapEvent, size)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		/*  Nothing more to publish.  */
+		close(events)
+		return events, func() {}
+	default:
+	}
+
+	r.subscribers = append(r.subscribers, events)
+	return events, func() { r.unsubscribe(events) }
+
+} /*  End of [exported] method  Reaper.Subscribe.  */
+
+// WaitFor Get a channel on which the reaper hands over the reap event of
+// the child with the given pid, once it reaps it. The channel is closed
+// after that - or without an event, if the reaper's Run returns first.
+// Any number of callers can wait for the same pid.
+//
+// Call it before the child can possibly die (e.g. right after starting
+// it), the reap of a child that is already gone is not replayed. For
+// children the reaper launches itself, see Supervise.
+func (r *Reaper) WaitFor(pid int) <-chan ReapEvent {
+	return r.watch(pid)
+
+} /*  End of [exported] method  Reaper.WaitFor.  */
+
+// MergeEvents Fan in the events from several channels (e.g. subscriptions
+// to multiple reapers) into a single channel. The returned channel is
+// closed once all of the input channels are closed - nil channels count
+// as closed. Events from any one input keep their order.
+//
+// There is no buffering or dropping here: a slow consumer holds up the
+// inputs, which for reaper subscriptions means that the reaper starts
+// dropping events for that subscriber. The consumer must keep reading
+// until the merged channel is closed, else the fan-in goroutines leak.
+func MergeEvents(chans ...<-chan ReapEvent) <-chan ReapEvent {
+	var wg sync.WaitGroup
+	merged := make(chan ReapEvent)
+
+	for _, events := range chans {
+		if events == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(events <-chan ReapEvent) {
+			defer wg.Done()
+			for event := range events {
+				merged <- event
+			}
+		}(events)
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	return merged
+
+} /*  End of [exported] function  MergeEvents.  */
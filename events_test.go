@@ -0,0 +1,85 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMergeEventsClose(t *testing.T) {
+	/*  No inputs at all (or only nil ones) is closed straight away.  */
+	for _, merged := range []<-chan ReapEvent{MergeEvents(), MergeEvents(nil, nil)} {
+		select {
+		case _, ok := <-merged:
+			if ok {
+				t.Fatalf("got an event without any inputs")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("merged channel not closed")
+		}
+	}
+
+	first, second := make(chan ReapEvent), make(chan ReapEvent)
+	merged := MergeEvents(first, nil, second)
+
+	close(first)
+	second <- ReapEvent{Pid: 42}
+	if event := <-merged; 42 != event.Pid {
+		t.Fatalf("merged pid %d, expected 42", event.Pid)
+	}
+
+	/*  Still open while the second input is.  */
+	select {
+	case event, ok := <-merged:
+		t.Fatalf("merged channel got %+v (open %v) with an input still open", event, ok)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(second)
+	select {
+	case _, ok := <-merged:
+		if ok {
+			t.Fatalf("got an event after all inputs closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("merged channel not closed once all inputs are")
+	}
+
+} /*  End of function  TestMergeEventsClose.  */
+
+func TestMergeEventsConcurrent(t *testing.T) {
+	const inputs, events = 8, 1000
+
+	chans := make([]<-chan ReapEvent, inputs)
+	var wg sync.WaitGroup
+	for idx := range chans {
+		input := make(chan ReapEvent)
+		chans[idx] = input
+
+		wg.Add(1)
+		go func(input chan<- ReapEvent, idx int) {
+			defer wg.Done()
+			defer close(input)
+			for seq := 1; seq <= events; seq++ {
+				input <- ReapEvent{Pid: idx, Seq: uint64(seq)}
+			}
+		}(input, idx)
+	}
+
+	last := make([]uint64, inputs)
+	count := 0
+	for event := range MergeEvents(chans...) {
+		if event.Seq != last[event.Pid]+1 {
+			t.Fatalf("input %d: seq %d after %d, expected the order kept", event.Pid, event.Seq, last[event.Pid])
+		}
+		last[event.Pid] = event.Seq
+		count++
+	}
+	wg.Wait()
+
+	if inputs*events != count {
+		t.Errorf("merged %d events, expected %d", count, inputs*events)
+	}
+
+} /*  End of function  TestMergeEventsConcurrent.  */
@@ -0,0 +1,61 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+// Check if an exit code is in the set.
+func hasExitCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+
+} /*  End of function  hasExitCode.  */
+
+// Check (and forget) whether a reaped child ended unexpectedly, given
+// whether or not we launched it. Only children we know what to expect
+// of can end unexpectedly: those we launched (expected to exit cleanly),
+// those with exit codes registered via ExpectExitCodes or any child if
+// Config.ExpectedExitCodes is set. Getting killed by a signal that the
+// reaper sent is never unexpected. Caller holds the lock.
+func (r *Reaper) unexpectedLocked(event ReapEvent, own bool) bool {
+	codes, registered := r.expected[event.Pid]
+	delete(r.expected, event.Pid)
+
+	if !own && !registered && r.config.ExpectedExitCodes == nil {
+		/*  No expectations, no disappointments.  */
+		return false
+	}
+
+	switch event.Outcome() {
+	case OutcomeExited:
+		return false
+
+	case OutcomeFailed:
+		code := event.Status.ExitStatus()
+		return !hasExitCode(codes, code) && !hasExitCode(r.config.ExpectedExitCodes, code)
+	}
+
+	return !event.SelfSignaled
+
+} /*  End of method  Reaper.unexpectedLocked.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// ExpectExitCodes Register the non-zero exit codes a child is expected to
+// exit with (on top of Config.ExpectedExitCodes), so that its reap event
+// is only marked Unexpected (and logged at warn level) for other exits.
+// The registration is dropped once the child is reaped.
+func (r *Reaper) ExpectExitCodes(pid int, codes ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expected[pid] = append(r.expected[pid], codes...)
+
+} /*  End of [exported] method  Reaper.ExpectExitCodes.  */
@@ -0,0 +1,73 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExpectExitCodes(t *testing.T) {
+	r := startTestReaper(t, Config{})
+	events, unsubscribe := r.Subscribe(16)
+	defer unsubscribe()
+
+	/*  Long enough a life to register its expectations first.  */
+	for _, test := range []struct {
+		script     string
+		expected   []int
+		unexpected bool
+	}{
+		{"sleep 0.2; exit 3", []int{3}, false},
+		{"sleep 0.2; exit 4", []int{3}, true},
+		{"sleep 0.2; exit 0", []int{3}, false},
+		{"sleep 0.2; exit 5", nil, false},
+	} {
+		pid := spawn(t, test.script)
+		if nil != test.expected {
+			r.ExpectExitCodes(pid, test.expected...)
+		}
+		if event := reapOf(t, events, pid); test.unexpected != event.Unexpected {
+			t.Errorf("%q expecting %v: unexpected %v", test.script, test.expected, event.Unexpected)
+		}
+	}
+
+	/*  Killed by someone else, or by the reaper itself.  */
+	pid := spawn(t, "exec sleep 10")
+	r.ExpectExitCodes(pid, 3)
+	syscall.Kill(pid, syscall.SIGKILL)
+	if event := reapOf(t, events, pid); !event.Unexpected {
+		t.Errorf("child killed from outside not unexpected")
+	}
+
+	pid = spawn(t, "exec sleep 10")
+	r.ExpectExitCodes(pid, 3)
+	if event, err := r.Terminate(pid, time.Second); err != nil || event.Unexpected {
+		t.Errorf("terminated child unexpected %v, err %v", event.Unexpected, err)
+	}
+
+} /*  End of function  TestExpectExitCodes.  */
+
+func TestExpectedExitCodes(t *testing.T) {
+	r := startTestReaper(t, Config{ExpectedExitCodes: []int{5}})
+	events, unsubscribe := r.Subscribe(16)
+	defer unsubscribe()
+
+	for _, test := range []struct {
+		script     string
+		unexpected bool
+	}{
+		{"exit 0", false},
+		{"exit 5", false},
+		{"exit 6", true},
+	} {
+		pid := spawn(t, test.script)
+		if event := reapOf(t, events, pid); test.unexpected != event.Unexpected {
+			t.Errorf("%q expecting exit code 5: unexpected %v", test.script, event.Unexpected)
+		}
+	}
+
+} /*  End of function  TestExpectedExitCodes.  */
@@ -0,0 +1,63 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// Serializes the check for a name being taken and publishing it, as
+// expvar.Publish panics on a name that is taken.
+var expvarMu sync.Mutex
+
+// The reaper stats as published via expvar.
+type expvarStats struct {
+	Uptime            float64    `json:"uptime_seconds"`
+	Reaped            uint64     `json:"reaped"`
+	LastReap          *time.Time `json:"last_reap"`
+	WaitErrors        uint64     `json:"wait_errors"`
+	DroppedSignals    uint64     `json:"dropped_signals"`
+	DroppedEvents     uint64     `json:"dropped_events"`
+	DroppedCallbacks  uint64     `json:"dropped_callbacks"`
+	DroppedSinkEvents uint64     `json:"dropped_sink_events"`
+}
+
+// Render the stats for expvar.
+func (r *Reaper) expvarStats() interface{} {
+	stats := r.Stats()
+
+	vars := expvarStats{
+		Uptime:            stats.Uptime.Seconds(),
+		Reaped:            stats.Reaped,
+		WaitErrors:        stats.WaitErrors,
+		DroppedSignals:    stats.DroppedSignals,
+		DroppedEvents:     stats.DroppedEvents,
+		DroppedCallbacks:  stats.DroppedCallbacks,
+		DroppedSinkEvents: stats.DroppedSinkEvents,
+	}
+	if !stats.LastReap.IsZero() {
+		vars.LastReap = &stats.LastReap
+	}
+
+	return vars
+
+} /*  End of method  Reaper.expvarStats.  */
+
+// Publish the stats via expvar under the given name, unless taken.
+func (r *Reaper) publishExpvar(name string) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		level.Warn(r.config.Logger).Log("msg", "expvar name taken, not publishing stats", "name", name)
+		return
+	}
+
+	expvar.Publish(name, expvar.Func(r.expvarStats))
+
+} /*  End of method  Reaper.publishExpvar.  */
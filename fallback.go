@@ -0,0 +1,96 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// FallbackFormat Format of the records written to stderr when the
+// configured logger fails to log a reaped child.
+type FallbackFormat int
+
+const (
+	// FallbackText A single, easy to grep line per child:
+	//   REAPER-FALLBACK ts=... pid=... status=... exit_code=... signal=... err="..."
+	FallbackText FallbackFormat = iota
+
+	// FallbackJSON A single line JSON object per child with the same
+	// keys as FallbackText plus "fallback": "REAPER-FALLBACK".
+	FallbackJSON
+
+	// FallbackOff Don't write anything, drop the record.
+	FallbackOff
+)
+
+const fallbackTag = "REAPER-FALLBACK"
+
+// String Name of the fallback format.
+func (f FallbackFormat) String() string {
+	switch f {
+	case FallbackText:
+		return "text"
+	case FallbackJSON:
+		return "json"
+	case FallbackOff:
+		return "off"
+	}
+
+	return "unknown"
+
+} /*  End of [exported] method  FallbackFormat.String.  */
+
+// Where the fallback records go, overridable for testing.
+var fallbackOutput io.Writer = os.Stderr
+
+// Format a fallback record for a reaped child that the logger failed to
+// log (with err). The record always ends with a newline.
+func formatFallback(format FallbackFormat, event ReapEvent, err error) []byte {
+	exitCode, sig := -1, 0
+	if event.Status.Exited() {
+		exitCode = event.Status.ExitStatus()
+	}
+	if event.Status.Signaled() {
+		sig = int(event.Status.Signal())
+	}
+
+	ts := event.Time.UTC().Format(time.RFC3339Nano)
+
+	switch format {
+	case FallbackJSON:
+		data, _ := json.Marshal(struct {
+			Fallback string `json:"fallback"`
+			Ts       string `json:"ts"`
+			Pid      int    `json:"pid"`
+			Status   int    `json:"status"`
+			ExitCode int    `json:"exit_code"`
+			Signal   int    `json:"signal"`
+			Err      string `json:"err"`
+		}{fallbackTag, ts, event.Pid, int(rawStatus(event.Status)), exitCode, sig, err.Error()})
+
+		return append(data, '\n')
+
+	case FallbackOff:
+		return nil
+	}
+
+	return []byte(fmt.Sprintf("%s ts=%s pid=%d status=%d exit_code=%d signal=%d err=%s\n",
+		fallbackTag, ts, event.Pid, int(rawStatus(event.Status)), exitCode, sig,
+		strconv.Quote(err.Error())))
+
+} /*  End of function  formatFallback.  */
+
+// The configured logger failed to log a reaped child - make sure the
+// critical bits still make it out to stderr.
+func (r *Reaper) fallback(event ReapEvent, err error) {
+	if record := formatFallback(r.config.FallbackFormat, event, err); len(record) > 0 {
+		fallbackOutput.Write(record)
+	}
+
+} /*  End of method  Reaper.fallback.  */
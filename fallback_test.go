@@ -0,0 +1,92 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// A buffer that is safe to write to from the reaper's goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write Implement io.Writer.
+func (b *syncBuffer) Write(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(data)
+
+} /*  End of [exported] method  syncBuffer.Write.  */
+
+// String What was written so far.
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+
+} /*  End of [exported] method  syncBuffer.String.  */
+
+func TestFormatFallback(t *testing.T) {
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	failure := errors.New(`disk "full"`)
+
+	exited := ReapEvent{Pid: 42, Status: syscall.WaitStatus(3 << 8), Time: when}
+	record := string(formatFallback(FallbackText, exited, failure))
+	expected := `REAPER-FALLBACK ts=2026-01-02T03:04:05Z pid=42 status=768 exit_code=3 signal=0 err="disk \"full\""` + "\n"
+	if expected != record {
+		t.Errorf("text record %q, expected %q", record, expected)
+	}
+
+	killed := ReapEvent{Pid: 43, Status: syscall.WaitStatus(syscall.SIGKILL), Time: when}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(formatFallback(FallbackJSON, killed, failure), &fields); err != nil {
+		t.Fatalf("json record doesn't parse: %v", err)
+	}
+	if "REAPER-FALLBACK" != fields["fallback"] || 43.0 != fields["pid"] || -1.0 != fields["exit_code"] || 9.0 != fields["signal"] || `disk "full"` != fields["err"] {
+		t.Errorf("json record %v", fields)
+	}
+
+	if record := formatFallback(FallbackOff, killed, failure); 0 != len(record) {
+		t.Errorf("record %q with the fallback off", record)
+	}
+
+} /*  End of function  TestFormatFallback.  */
+
+func TestFallbackOnLoggerFailure(t *testing.T) {
+	output := &syncBuffer{}
+	saved := fallbackOutput
+	fallbackOutput = output
+	t.Cleanup(func() { fallbackOutput = saved })
+
+	broken := log.LoggerFunc(func(keyvals ...interface{}) error {
+		return errors.New("logger broken")
+	})
+	startTestReaper(t, Config{Logger: broken})
+
+	pid := spawn(t, "exit 7")
+	eventually(t, "the fallback record", func() bool {
+		return strings.Contains(output.String(), "exit_code=7")
+	})
+
+	record := output.String()
+	if !strings.HasPrefix(record, "REAPER-FALLBACK ts=") || !strings.Contains(record, fmt.Sprintf(" pid=%d ", pid)) || !strings.Contains(record, `err="logger broken"`) {
+		t.Errorf("fallback record %q", record)
+	}
+
+} /*  End of function  TestFallbackOnLoggerFailure.  */
@@ -0,0 +1,98 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/go-kit/log/level"
+)
+
+// The signals to pass on to the primary child.
+func (r *Reaper) forwardedSignals() []os.Signal {
+	if r.config.ForwardSignals != nil {
+		return r.config.ForwardSignals
+	}
+
+	return initSignals
+
+} /*  End of method  Reaper.forwardedSignals.  */
+
+// Pass a signal on to the primary child.
+func (r *Reaper) forward(pid int, sig os.Signal) {
+	logger := r.config.Logger
+
+	ssig, ok := sig.(Signal)
+	if !ok {
+		return
+	}
+
+	group := r.config.KillProcessGroup
+	level.Debug(logger).Log("msg", "forwarding signal", "signal", sig, "pid", pid, "group", group)
+
+	r.mu.Lock()
+	err := r.signalLocked(pid, ssig, group)
+	r.mu.Unlock()
+
+	if err != nil {
+		level.Warn(logger).Log("msg", "failed to forward signal", "signal", sig, "pid", pid, "err", err)
+	}
+
+} /*  End of method  Reaper.forward.  */
+
+// Pass the given signals on to the child with the given pid, until the
+// returned stop function is called.
+func (r *Reaper) forwardSignals(pid int, sigs []os.Signal) (stop func()) {
+	if 0 == len(sigs) {
+		/*  Notify without any signals would relay all of them.  */
+		return func() {}
+	}
+
+	incoming := make(chan os.Signal, len(sigs))
+	signal.Notify(incoming, sigs...)
+
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-quit:
+				return
+			case sig := <-incoming:
+				r.forward(pid, sig)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(incoming)
+			close(quit)
+			<-done
+		})
+	}
+
+} /*  End of method  Reaper.forwardSignals.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// ForwardSignals Make the child with the given pid the primary child and
+// pass the signals in Config.ForwardSignals on to it, until the returned
+// stop function is called (which waits for the forwarding to be done).
+// Signals are sent as per Signal (or to the child's process group, see
+// Config.KillProcessGroup), so a child killed by one is tagged as
+// SelfSignaled. RunAsInit does this for its child, call it yourself for
+// a primary child launched otherwise (e.g. via Supervise).
+func (r *Reaper) ForwardSignals(pid int) (stop func()) {
+	return r.forwardSignals(pid, r.forwardedSignals())
+
+} /*  End of [exported] method  Reaper.ForwardSignals.  */
@@ -1,5 +1,7 @@
 module github.com/kakkoyun/go-reaper
 
-go 1.14
+go 1.21
 
 require github.com/go-kit/log v0.2.0
+
+require github.com/go-logfmt/logfmt v0.5.1 // indirect
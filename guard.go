@@ -0,0 +1,65 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"sync"
+)
+
+// The reapers of this process that are running (waiting on children), if
+// any. Two of them waiting on the same children would both catch every
+// SIGCHLD and race on wait4(2), each getting some of the statuses the
+// other is after. Reapers of distinct process groups (see TargetGroup)
+// never wait on the same child, so they get to run side by side.
+var active struct {
+	mu      sync.Mutex
+	reapers map[*Reaper]struct{}
+}
+
+// The process group a reaper is restricted to, 0 if it waits on children
+// outside of a single process group.
+func (r *Reaper) group() int {
+	switch {
+	case r.config.TargetGroup > 0:
+		return r.config.TargetGroup
+	case r.config.Pid < -1:
+		return -r.config.Pid
+	}
+
+	return 0
+
+} /*  End of method  Reaper.group.  */
+
+// Take the guard for the given reaper. Fails with ErrAlreadyRunning if
+// another reaper that may wait on the same children holds it.
+func acquire(r *Reaper) error {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+
+	group := r.group()
+	for other := range active.reapers {
+		if other == r {
+			continue
+		}
+		if theirs := other.group(); 0 == group || 0 == theirs || group == theirs {
+			return ErrAlreadyRunning
+		}
+	}
+
+	if nil == active.reapers {
+		active.reapers = make(map[*Reaper]struct{})
+	}
+	active.reapers[r] = struct{}{}
+	return nil
+
+} /*  End of function  acquire.  */
+
+// Release the guard, if the given reaper holds it.
+func release(r *Reaper) {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+
+	delete(active.reapers, r)
+
+} /*  End of function  release.  */
@@ -0,0 +1,55 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+// A storm of SIGCHLDs through the signal handler, with the reap loop
+// taking the wakeups off its hands as fast as it can. Reports how many
+// of the signals made it to the reap loop, and how many were dropped.
+func benchmarkSigChildHandler(b *testing.B, maxWakeups int) {
+	r := New(Config{Pid: -1, MaxWakeupsPerSecond: maxWakeups, Logger: log.NewNopLogger()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 64)
+	notifications := make(chan os.Signal, 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.sigChildHandler(ctx, sigs, notifications)
+	}()
+
+	var wakeups atomic.Int64
+	go func() {
+		for range notifications {
+			wakeups.Add(1)
+		}
+	}()
+
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		sigs <- sigCHLD
+	}
+	b.StopTimer()
+
+	cancel()
+	<-done
+	close(notifications)
+
+	b.ReportMetric(float64(wakeups.Load())/float64(b.N), "wakeups/signal")
+	b.ReportMetric(float64(r.Stats().DroppedSignals)/float64(b.N), "drops/signal")
+
+} /*  End of function  benchmarkSigChildHandler.  */
+
+func BenchmarkSigChildHandler(b *testing.B) {
+	b.Run("unlimited", func(b *testing.B) { benchmarkSigChildHandler(b, 0) })
+	b.Run("limited", func(b *testing.B) { benchmarkSigChildHandler(b, 100) })
+
+} /*  End of function  BenchmarkSigChildHandler.  */
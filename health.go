@@ -0,0 +1,172 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const healthShutdownTimeout = 5 * time.Second
+
+// Liveness as reported by /healthz.
+type health struct {
+	Status       string
+	LastActivity time.Time
+}
+
+// Mark the reap loop as running (or not). The first time round, this
+// also lets Embed know the reaper is up.
+func (r *Reaper) setRunning(running bool) {
+	r.statsMu.Lock()
+	r.running = running
+	r.lastActivity = time.Now()
+	if running {
+		r.stats.Started = r.lastActivity
+	} else if !r.stats.Started.IsZero() {
+		r.stats.Uptime = r.lastActivity.Sub(r.stats.Started)
+	}
+	r.statsMu.Unlock()
+
+	if running {
+		r.readyOnce.Do(func() { close(r.ready) })
+	}
+
+} /*  End of method  Reaper.setRunning.  */
+
+// Note whether the SIGCHLD handler is running.
+func (r *Reaper) setHandling(handling bool) {
+	r.statsMu.Lock()
+	r.handling = handling
+	r.statsMu.Unlock()
+
+} /*  End of method  Reaper.setHandling.  */
+
+// Note that the reap loop is alive and kicking.
+func (r *Reaper) touch() {
+	r.statsMu.Lock()
+	r.lastActivity = time.Now()
+	r.statsMu.Unlock()
+
+} /*  End of method  Reaper.touch.  */
+
+// Check if the reap loop, last seen active at the given time, has been
+// quiet for too long with children to wait on (see HealthStaleAfter).
+func (r *Reaper) stale(active time.Time) bool {
+	if 0 == r.config.HealthStaleAfter || time.Since(active) <= r.config.HealthStaleAfter {
+		return false
+	}
+
+	return len(r.Children()) > 0
+
+} /*  End of method  Reaper.stale.  */
+
+// Serve /healthz - 200 while the reaper is healthy, 503 otherwise.
+func (r *Reaper) serveHealthz(w http.ResponseWriter, req *http.Request) {
+	r.statsMu.Lock()
+	active := r.lastActivity
+	r.statsMu.Unlock()
+
+	status := health{Status: "ok", LastActivity: active}
+	code := http.StatusOK
+	switch err := r.Healthy(); {
+	case errors.Is(err, ErrNotRunning):
+		status.Status = "stopped"
+		code = http.StatusServiceUnavailable
+	case err != nil:
+		status.Status = err.Error()
+		code = http.StatusServiceUnavailable
+	case r.stale(active):
+		status.Status = "stale"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+
+} /*  End of method  Reaper.serveHealthz.  */
+
+// Serve /stats - the reaper's stats as JSON.
+func (r *Reaper) serveStats(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(r.Stats())
+
+} /*  End of method  Reaper.serveStats.  */
+
+// Start the health server on the given address. Returns a function that
+// shuts the server down.
+func (r *Reaper) startHealthServer(addr string) (func(), error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", r.serveHealthz)
+	mux.HandleFunc("/stats", r.serveStats)
+
+	server := &http.Server{Handler: mux}
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		server.Serve(listener)
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), healthShutdownTimeout)
+		defer cancel()
+
+		server.Shutdown(ctx)
+		<-served
+	}, nil
+
+} /*  End of method  Reaper.startHealthServer.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Healthy Check that the reaper is in working order: the reap loop is
+// running (ErrNotRunning otherwise) and so is the SIGCHLD handler, with
+// SIGCHLD not ignored behind its back (ErrNoSignalHandler otherwise).
+// Fit for readiness probes - as a func() error, it can be passed as is
+// wherever a healthcheck.Check is expected.
+func (r *Reaper) Healthy() error {
+	r.statsMu.Lock()
+	running, handling := r.running, r.handling
+	r.statsMu.Unlock()
+
+	if !running {
+		return ErrNotRunning
+	}
+	if !handling {
+		return ErrNoSignalHandler
+	}
+
+	/*  With SIGCHLD ignored, the signals just never come.  */
+	if auto, err := autoReaping(); err == nil && auto && !r.config.AssumeAutoReap {
+		return fmt.Errorf("%w: SIGCHLD ignored or SA_NOCLDWAIT set", ErrNoSignalHandler)
+	}
+
+	return nil
+
+} /*  End of [exported] method  Reaper.Healthy.  */
+
+// HealthCheck Healthy, for health check libraries that pass a context.
+func (r *Reaper) HealthCheck(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.Healthy()
+
+} /*  End of [exported] method  Reaper.HealthCheck.  */
@@ -0,0 +1,142 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// A free local address to serve on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't listen: %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+
+} /*  End of function  freeAddr.  */
+
+func TestHealthEndpoints(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	spawn(t, "exit 5")
+	eventually(t, "the reap", func() bool { return 1 == r.Stats().Reaped })
+
+	rec := httptest.NewRecorder()
+	r.serveHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	var status health
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil || http.StatusOK != rec.Code || "ok" != status.Status || status.LastActivity.IsZero() {
+		t.Errorf("/healthz answered %d with %+v (%v)", rec.Code, status, err)
+	}
+
+	rec = httptest.NewRecorder()
+	r.serveStats(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+	var stats Stats
+	if err := json.NewDecoder(rec.Body).Decode(&stats); err != nil || 1 != stats.Reaped || 1 != stats.ExitCodes["5"] {
+		t.Errorf("/stats answered %+v (%v), expected the reap", stats, err)
+	}
+
+	r.Stop()
+
+	rec = httptest.NewRecorder()
+	r.serveHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil || http.StatusServiceUnavailable != rec.Code || "stopped" != status.Status {
+		t.Errorf("/healthz of a stopped reaper answered %d with %+v (%v)", rec.Code, status, err)
+	}
+
+} /*  End of function  TestHealthEndpoints.  */
+
+func TestHealthServerLifecycle(t *testing.T) {
+	addr := freeAddr(t)
+	r := startTestReaper(t, Config{HealthAddr: addr})
+
+	resp, err := http.Get("http://" + addr + "/healthz")
+	if err != nil {
+		t.Fatalf("health server not up: %v", err)
+	}
+	resp.Body.Close()
+	if http.StatusOK != resp.StatusCode {
+		t.Errorf("/healthz answered %s", resp.Status)
+	}
+
+	r.Stop()
+	if resp, err := http.Get("http://" + addr + "/healthz"); nil == err {
+		resp.Body.Close()
+		t.Errorf("health server still up once the reaper stopped")
+	}
+
+} /*  End of function  TestHealthServerLifecycle.  */
+
+func TestHealthStale(t *testing.T) {
+	r := startTestReaper(t, Config{HealthStaleAfter: time.Minute})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	healthz := func() (int, health) {
+		rec := httptest.NewRecorder()
+		r.serveHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		var status health
+		json.NewDecoder(rec.Body).Decode(&status)
+		return rec.Code, status
+	}
+	quiet := func() {
+		r.statsMu.Lock()
+		r.lastActivity = time.Now().Add(-time.Hour)
+		r.statsMu.Unlock()
+	}
+
+	keeper := blockSweep(t, r, events)
+	if code, status := healthz(); http.StatusOK != code {
+		t.Errorf("/healthz of a waiting reaper answered %d with %+v", code, status)
+	}
+
+	/*  As if it got stuck, with the child still there.  */
+	quiet()
+	if code, status := healthz(); http.StatusServiceUnavailable != code || "stale" != status.Status {
+		t.Errorf("/healthz of a stuck reaper answered %d with %+v", code, status)
+	}
+
+	/*  Nothing to reap, nothing to be stuck on.  */
+	syscall.Kill(keeper, syscall.SIGKILL)
+	reapOf(t, events, keeper)
+	eventually(t, "the child out of the tree", func() bool { return 0 == len(r.Children()) })
+
+	quiet()
+	if code, status := healthz(); http.StatusOK != code {
+		t.Errorf("/healthz of an idle reaper answered %d with %+v", code, status)
+	}
+
+} /*  End of function  TestHealthStale.  */
+
+func TestHealthHeartbeat(t *testing.T) {
+	r := startTestReaper(t, Config{HealthStaleAfter: 50 * time.Millisecond})
+	events, unsubscribe := r.Subscribe(32)
+	defer unsubscribe()
+
+	lastActivity := func() time.Time {
+		r.statsMu.Lock()
+		defer r.statsMu.Unlock()
+		return r.lastActivity
+	}
+
+	/*  Waiting on a living child, but checking in all the same.  */
+	blockSweep(t, r, events)
+	since := lastActivity()
+	eventually(t, "a heartbeat", func() bool { return lastActivity().After(since) })
+
+} /*  End of function  TestHealthHeartbeat.  */
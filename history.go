@@ -0,0 +1,77 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+// eventRing Bounded history of reap events, once full the oldest event
+// is overwritten.
+type eventRing struct {
+	events []ReapEvent
+	next   int
+	full   bool
+}
+
+// Create a ring holding up to size events, nil if size isn't positive.
+func newEventRing(size int) *eventRing {
+	if size <= 0 {
+		return nil
+	}
+
+	return &eventRing{events: make([]ReapEvent, size)}
+
+} /*  End of function  newEventRing.  */
+
+// Record an event, evicting the oldest one if the ring is full.
+func (ring *eventRing) add(event ReapEvent) {
+	ring.events[ring.next] = event
+	ring.next++
+	if ring.next == len(ring.events) {
+		ring.next = 0
+		ring.full = true
+	}
+
+} /*  End of method  eventRing.add.  */
+
+// Copy of the events in the ring, oldest first.
+func (ring *eventRing) snapshot() []ReapEvent {
+	if ring == nil {
+		return nil
+	}
+
+	if !ring.full {
+		return append([]ReapEvent(nil), ring.events[:ring.next]...)
+	}
+
+	events := make([]ReapEvent, 0, len(ring.events))
+	events = append(events, ring.events[ring.next:]...)
+	return append(events, ring.events[:ring.next]...)
+
+} /*  End of method  eventRing.snapshot.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// RecentFailures The last Config.FailureHistorySize reaps of children
+// that failed (non-zero exit code), were killed by a signal or dumped
+// core, oldest first. Clean exits are not kept here, so they can't push
+// the failures out no matter how many children come and go. Empty
+// unless Config.FailureHistorySize is set.
+func (r *Reaper) RecentFailures() []ReapEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.failures.snapshot()
+
+} /*  End of [exported] method  Reaper.RecentFailures.  */
+
+// RecentEvents The last Config.EventHistorySize reaps, whatever their
+// outcome, oldest first. Empty unless Config.EventHistorySize is set.
+func (r *Reaper) RecentEvents() []ReapEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.history.snapshot()
+
+} /*  End of [exported] method  Reaper.RecentEvents.  */
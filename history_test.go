@@ -0,0 +1,80 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"reflect"
+	"testing"
+)
+
+// The pids of a bunch of events, in order.
+func pidsOf(events []ReapEvent) []int {
+	pids := []int{}
+	for _, event := range events {
+		pids = append(pids, event.Pid)
+	}
+
+	return pids
+
+} /*  End of function  pidsOf.  */
+
+func TestEventRing(t *testing.T) {
+	if ring := newEventRing(0); nil != ring || nil != ring.snapshot() {
+		t.Fatalf("got a ring of size 0")
+	}
+
+	ring := newEventRing(3)
+	for pid, expected := range [][]int{
+		{},
+		{1},
+		{1, 2},
+		{1, 2, 3},
+		{2, 3, 4},
+		{3, 4, 5},
+		{4, 5, 6},
+		{5, 6, 7},
+	} {
+		if 0 != pid {
+			ring.add(ReapEvent{Pid: pid})
+		}
+		if got := pidsOf(ring.snapshot()); !reflect.DeepEqual(expected, got) {
+			t.Errorf("after %d events: %v, expected %v", pid, got, expected)
+		}
+	}
+
+	/*  A snapshot is a copy, not a view of the ring.  */
+	snapshot := ring.snapshot()
+	ring.add(ReapEvent{Pid: 8})
+	if got := pidsOf(snapshot); !reflect.DeepEqual([]int{5, 6, 7}, got) {
+		t.Errorf("snapshot changed to %v with the ring", got)
+	}
+
+} /*  End of function  TestEventRing.  */
+
+func TestRecentFailures(t *testing.T) {
+	r := startTestReaper(t, Config{FailureHistorySize: 2, EventHistorySize: 3})
+	events, unsubscribe := r.Subscribe(16)
+	defer unsubscribe()
+
+	var pids []int
+	for _, script := range []string{"exit 1", "exit 0", "kill -KILL $$", "exit 0", "exit 3", "exit 0"} {
+		pid := spawn(t, script)
+		reapOf(t, events, pid)
+		pids = append(pids, pid)
+	}
+
+	/*  The clean exits don't push out the failures.  */
+	if got := pidsOf(r.RecentFailures()); !reflect.DeepEqual([]int{pids[2], pids[4]}, got) {
+		t.Errorf("recent failures %v, expected %v", got, []int{pids[2], pids[4]})
+	}
+	if got := pidsOf(r.RecentEvents()); !reflect.DeepEqual(pids[3:], got) {
+		t.Errorf("recent events %v, expected %v", got, pids[3:])
+	}
+
+	if failures := New(Config{}).RecentFailures(); 0 != len(failures) {
+		t.Errorf("got %d recent failures without a history", len(failures))
+	}
+
+} /*  End of function  TestRecentFailures.  */
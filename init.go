@@ -0,0 +1,177 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// RunAsInit Run as a (tini style) init: start the reaper, spawn cmd as
+// the primary child, pass the signals we get on to it (see
+// Config.ForwardSignals) and reap all the orphans until it exits.
+// Returns the primary child's exit code (128 plus the signal number if a
+// signal killed it, see ExitCode) once the reaper stopped as well - for
+// the caller to exit with, so that the container exits the way it did.
+//
+// The primary child is waited on by us, the reaper leaves it alone (see
+// Register). Cancelling ctx kills it, just like exec.CommandContext - or
+// sends it a SIGTERM first, with a Config.GracePeriod.
+// The reaper itself runs until the primary child is gone, an error is
+// only returned if the reaper or the child failed to start, or if the
+// reaper didn't stop cleanly.
+func (r *Reaper) RunAsInit(ctx context.Context, cmd *exec.Cmd) (int, error) {
+	logger := r.config.Logger
+
+	/*  The reaper outlives ctx, for as long as the child is around.  */
+	rctx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- r.Run(rctx)
+	}()
+
+	select {
+	case err := <-errs:
+		return -1, err
+	case <-r.ready:
+	}
+
+	/*
+	 *  A session or a foreground job is a process group of its own.
+	 *  Only a process group in the background doesn't hear of the
+	 *  terminal being resized, we have to tell it.
+	 */
+	var (
+		background bool
+		resizes    bool
+		proxy      *ptyProxy
+	)
+	switch {
+	case r.config.InitPTY:
+		var err error
+		if proxy, err = newPTYProxy(cmd); err != nil {
+			stop()
+			<-errs
+			return -1, err
+		}
+	case r.config.InitSession:
+		newSession(cmd)
+	case r.config.InitForeground && foreground(cmd):
+		background = true
+	case r.config.KillProcessGroup:
+		stdin, ok := stdinOf(cmd)
+		resizes = ok && isTerminal(stdin.Fd())
+		isolate(cmd)
+	}
+
+	primary := &Cmd{Cmd: cmd, reaper: r}
+	if err := primary.Start(); err != nil {
+		if proxy != nil {
+			proxy.slave.Close()
+			proxy.master.Close()
+		}
+		stop()
+		<-errs
+		return -1, err
+	}
+
+	if background {
+		/*  Not before the child is started, it'd inherit that.  */
+		defer ignoreJobControl()()
+	}
+
+	pid := cmd.Process.Pid
+	level.Info(logger).Log("msg", "started primary child", "path", cmd.Path, "pid", pid)
+
+	if proxy != nil {
+		proxy.start(logger)
+	}
+
+	sigs := r.forwardedSignals()
+	if resizes && sigWINCH != nil {
+		sigs = append(sigs[:len(sigs):len(sigs)], sigWINCH)
+	}
+
+	stopForwarding := r.forwardSignals(pid, sigs)
+	defer stopForwarding()
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- primary.Wait()
+	}()
+
+	var (
+		waitErr error
+		grace   <-chan time.Time
+	)
+
+	done := ctx.Done()
+	for waiting := true; waiting; {
+		select {
+		case <-done:
+			done = nil
+			if r.config.GracePeriod > 0 {
+				level.Info(logger).Log("msg", "terminating primary child", "pid", pid, "grace_period", r.config.GracePeriod, "err", ctx.Err())
+				r.forward(pid, sigTERM)
+				grace = time.After(r.config.GracePeriod)
+				continue
+			}
+			level.Info(logger).Log("msg", "killing primary child", "pid", pid, "err", ctx.Err())
+			cmd.Process.Kill()
+		case <-grace:
+			level.Warn(logger).Log("msg", "killing primary child", "pid", pid, "grace_period", r.config.GracePeriod)
+			cmd.Process.Kill()
+		case waitErr = <-exited:
+			stopForwarding()
+			waiting = false
+		}
+	}
+
+	if proxy != nil {
+		proxy.stop()
+	}
+
+	/*  Exiting non-zero is what the exit code is for, not an error.  */
+	var exitErr *ExitError
+	if errors.As(waitErr, &exitErr) {
+		waitErr = nil
+	}
+
+	/*  The reaper did the waiting, the status is in the reap event.  */
+	code := -1
+	if nil == waitErr {
+		wstatus := primary.Event.Status
+		code = ExitCode(wstatus)
+
+		keyvals := append([]interface{}{"msg", "primary child exited", "pid", pid}, statusKeyvals(wstatus)...)
+		level.Info(logger).Log(append(keyvals, "exit_with", code)...)
+	}
+
+	stop()
+	if err := <-errs; !IsCleanShutdown(err) {
+		return code, err
+	}
+
+	return code, waitErr
+
+} /*  End of [exported] method  Reaper.RunAsInit.  */
+
+// RunAsInit Run cmd as the primary child of a default reaper (which has
+// to be pid 1 in a container), see Reaper.RunAsInit.
+func RunAsInit(ctx context.Context, cmd *exec.Cmd) (int, error) {
+	return New(Config{Pid: -1}).RunAsInit(ctx, cmd)
+
+} /*  End of [exported] function  RunAsInit.  */
@@ -0,0 +1,75 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// Run a shell script as the primary child of a fresh reaper, returning
+// what RunAsInit does.
+func runAsInit(t *testing.T, ctx context.Context, config Config, script string) (int, error) {
+	t.Helper()
+
+	config.Pid = -1
+	config.DisablePid1Check = true
+	config.AllowHostReaping = true
+	config.Logger = log.NewNopLogger()
+
+	return New(config).RunAsInit(ctx, exec.Command("/bin/sh", "-c", script))
+
+} /*  End of function  runAsInit.  */
+
+func TestRunAsInit(t *testing.T) {
+	for _, test := range []struct {
+		script string
+		code   int
+	}{
+		{"exit 0", 0},
+		{"exit 3", 3},
+		{"kill -TERM $$", 143},
+	} {
+		code, err := runAsInit(t, context.Background(), Config{}, test.script)
+		if err != nil || test.code != code {
+			t.Errorf("%q: exit code %d (err %v), expected %d", test.script, code, err, test.code)
+		}
+	}
+
+} /*  End of function  TestRunAsInit.  */
+
+func TestRunAsInitSignaled(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGINT, syscall.SIGKILL, syscall.SIGUSR1} {
+		code, err := runAsInit(t, context.Background(), Config{}, "kill -"+strconv.Itoa(int(sig))+" $$")
+		if err != nil || 128+int(sig) != code {
+			t.Errorf("killed by %v: exit code %d (err %v), expected %d", sig, code, err, 128+int(sig))
+		}
+	}
+
+	/*  Stopped by us: a SIGTERM with a grace period, a SIGKILL without.  */
+	for _, test := range []struct {
+		grace time.Duration
+		code  int
+	}{
+		{time.Second, 128 + int(syscall.SIGTERM)},
+		{0, 128 + int(syscall.SIGKILL)},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		code, err := runAsInit(t, ctx, Config{GracePeriod: test.grace}, "exec sleep 10")
+		if err != nil || test.code != code {
+			t.Errorf("cancelled with a %v grace period: exit code %d (err %v), expected %d", test.grace, code, err, test.code)
+		}
+		cancel()
+	}
+
+} /*  End of function  TestRunAsInitSignaled.  */
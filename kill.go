@@ -0,0 +1,175 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// Bit for a signal in the per-pid mask of signals we sent.
+func signalBit(sig Signal) uint64 {
+	if sig <= 0 || sig > 64 {
+		return 0
+	}
+
+	return 1 << uint(sig-1)
+
+} /*  End of function  signalBit.  */
+
+// Where to send a signal meant for a child's whole process group: the
+// group, unless the child is in ours - we'd be signalling ourselves.
+func groupTarget(pid int) (int, error) {
+	pgid, err := getpgid(pid)
+	if err != nil {
+		return 0, err
+	}
+	if pgid == getpgrp() {
+		return pid, nil
+	}
+
+	return -pgid, nil
+
+} /*  End of function  groupTarget.  */
+
+// Send a signal to a child (or its whole process group), recording that
+// we sent it. Caller holds the lock, so the record is in place before the
+// child can get reaped.
+func (r *Reaper) signalLocked(pid int, sig Signal, group bool) error {
+	target := pid
+	if group {
+		var err error
+		if target, err = groupTarget(pid); err != nil {
+			return err
+		}
+	}
+
+	send := func() error { return kill(target, sig) }
+	if pidfd, ok := r.pidfds[pid]; ok && target == pid {
+		send = func() error { return pidfdSignal(pidfd, sig) }
+	}
+
+	bit := signalBit(sig)
+	old := r.signaled[pid]
+	r.signaled[pid] = old | bit
+
+	if err := send(); err != nil {
+		if 0 == old {
+			delete(r.signaled, pid)
+		} else {
+			r.signaled[pid] = old
+		}
+		return err
+	}
+
+	return nil
+
+} /*  End of method  Reaper.signalLocked.  */
+
+// Check (and forget) whether the reaper itself sent the signal that
+// killed a reaped child. Caller holds the lock.
+func (r *Reaper) selfSignaledLocked(pid int, wstatus WaitStatus) bool {
+	sent, ok := r.signaled[pid]
+	if !ok {
+		return false
+	}
+
+	delete(r.signaled, pid)
+	return wstatus.Signaled() && 0 != sent&signalBit(wstatus.Signal())
+
+} /*  End of method  Reaper.selfSignaledLocked.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Signal Send a signal to a child, keeping track of it so that if the
+// signal kills the child, its reap event is tagged as SelfSignaled.
+func (r *Reaper) Signal(pid int, sig Signal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.signalLocked(pid, sig, false)
+
+} /*  End of [exported] method  Reaper.Signal.  */
+
+// KillAll Send a signal to all the children the reaper launched itself
+// (see Supervise), tagging their reap events as SelfSignaled if that is
+// what kills them. Returns the first error, if any, but does try to
+// signal all of them.
+func (r *Reaper) KillAll(sig Signal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for pid := range r.waiters {
+		if err := r.signalLocked(pid, sig, false); err != nil {
+			level.Debug(r.config.Logger).Log("msg", "failed to signal child", "pid", pid, "signal", sig, "err", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+
+} /*  End of [exported] method  Reaper.KillAll.  */
+
+// Terminate Stop a child the way an init would: send it a SIGTERM, give it
+// up to grace to exit and SIGKILL it if it hasn't, waiting for the
+// reaper to reap it either way. Returns the child's reap event, tagged
+// as SelfSignaled if one of our signals killed it. A zero grace means
+// Config.GracePeriod - and if that is zero too, the SIGKILL follows the
+// SIGTERM right away.
+//
+// Works for any child the reaper waits on, launched or claimed or not
+// (see WaitFor), but not for registered ones - their owners do the
+// waiting. Fails with the error from signalling the child if it is gone
+// already, with ErrNotRunning if the reaper stops first and with
+// ErrNotReaped if the child is still around after the SIGKILL.
+func (r *Reaper) Terminate(pid int, grace time.Duration) (ReapEvent, error) {
+	if grace <= 0 {
+		grace = r.config.GracePeriod
+	}
+
+	reaped := r.watch(pid)
+	if err := r.Signal(pid, sigTERM); err != nil {
+		r.unwatch(pid, reaped)
+		return ReapEvent{}, err
+	}
+
+	wait := func(timeout time.Duration) (ReapEvent, bool, error) {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case event, ok := <-reaped:
+			if !ok {
+				return ReapEvent{}, true, ErrNotRunning
+			}
+			return event, true, nil
+		case <-timer.C:
+			return ReapEvent{}, false, nil
+		}
+	}
+
+	if event, done, err := wait(grace); done {
+		return event, err
+	}
+
+	level.Info(r.config.Logger).Log("msg", "killing child", "pid", pid, "grace_period", grace)
+
+	/*  A child gone since is just about to be reaped - no harm done.  */
+	r.Signal(pid, sigKILL)
+	if event, done, err := wait(killReapTimeout); done {
+		return event, err
+	}
+
+	r.unwatch(pid, reaped)
+	return ReapEvent{}, ErrNotReaped
+
+} /*  End of [exported] method  Reaper.Terminate.  */
@@ -0,0 +1,76 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestTerminate(t *testing.T) {
+	if _, err := os.Stat("/proc/self/comm"); err != nil {
+		t.Skipf("no /proc to tell when the trap is set: %v", err)
+	}
+
+	const grace = 200 * time.Millisecond
+	r := startTestReaper(t, Config{GracePeriod: grace})
+
+	/*  Gone on the SIGTERM, no waiting on the grace period.  */
+	started := time.Now()
+	event, err := r.Terminate(spawn(t, "exec sleep 10"), 0)
+	if err != nil || syscall.SIGTERM != event.Status.Signal() || !event.SelfSignaled {
+		t.Errorf("terminated child reaped with %#x, self signaled %v (%v), expected a SIGTERM of ours", uint32(event.Status), event.SelfSignaled, err)
+	}
+	if took := time.Since(started); took >= grace {
+		t.Errorf("terminated child reaped after %v, expected it before the grace period was up", took)
+	}
+
+	/*  Killed once the grace period is up, Config.GracePeriod's or ours.  */
+	for _, test := range []struct {
+		grace    time.Duration
+		expected time.Duration
+	}{
+		{0, grace},
+		{2 * grace, 2 * grace},
+	} {
+		pid := spawnStubborn(t)
+
+		started := time.Now()
+		event, err := r.Terminate(pid, test.grace)
+		if err != nil || syscall.SIGKILL != event.Status.Signal() || !event.SelfSignaled {
+			t.Errorf("grace %v: child reaped with %#x, self signaled %v (%v), expected a SIGKILL of ours", test.grace, uint32(event.Status), event.SelfSignaled, err)
+		}
+		if took := time.Since(started); took < test.expected || took > test.expected+time.Second {
+			t.Errorf("grace %v: child killed after %v, expected %v", test.grace, took, test.expected)
+		}
+	}
+
+	/*  No such child.  */
+	pid := spawn(t, "exit 0")
+	eventually(t, "the reap", func() bool { return nil != syscall.Kill(pid, 0) })
+	if _, err := r.Terminate(pid, grace); !errors.Is(err, syscall.ESRCH) {
+		t.Errorf("terminated a child that is gone: %v", err)
+	}
+
+} /*  End of function  TestTerminate.  */
+
+func TestTerminateNotReaped(t *testing.T) {
+	fastKillReaps(t, 100*time.Millisecond)
+	r := startTestReaper(t, Config{})
+
+	/*  Registered, someone else does the waiting - no reap to wait for.  */
+	pid := spawn(t, "exec sleep 10")
+	r.Register(pid)
+	defer r.Unregister(pid)
+	defer syscall.Wait4(pid, nil, 0, nil)
+
+	if _, err := r.Terminate(pid, 100*time.Millisecond); !errors.Is(err, ErrNotReaped) {
+		t.Errorf("terminating a child the reaper doesn't reap failed with %v, expected ErrNotReaped", err)
+	}
+
+} /*  End of function  TestTerminateNotReaped.  */
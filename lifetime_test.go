@@ -0,0 +1,79 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"testing"
+	"time"
+)
+
+func TestProcAge(t *testing.T) {
+	proc := newFakeProc(t)
+
+	/*  Started 50 seconds after boot, up for 100.5 seconds.  */
+	proc.write("uptime", "100.50 180.25\n")
+	if age, err := procAge(procStat{pid: 42, starttime: 50 * procTicks}); err != nil || 50500*time.Millisecond != age {
+		t.Errorf("age %v (err %v), expected %v", age, err, 50500*time.Millisecond)
+	}
+
+	if _, err := procAge(procStat{pid: 42}); nil == err {
+		t.Errorf("got an age without a start time")
+	}
+
+	for _, uptime := range []string{"", "up\n"} {
+		proc.write("uptime", uptime)
+		if _, err := procAge(procStat{pid: 42, starttime: 1}); nil == err {
+			t.Errorf("got an age with uptime %q", uptime)
+		}
+	}
+
+} /*  End of function  TestProcAge.  */
+
+func TestTrackLifetimes(t *testing.T) {
+	proc := newFakeProc(t)
+	proc.write("uptime", "100.50 180.25\n")
+
+	stat := procStat{pid: 42, ppid: 1, starttime: 50 * procTicks}
+	if age := New(Config{TrackLifetimes: true}).inspectStat(stat); 50500*time.Millisecond != age {
+		t.Errorf("tracked lifetime %v, expected %v", age, 50500*time.Millisecond)
+	}
+	if age := New(Config{}).inspectStat(stat); 0 != age {
+		t.Errorf("lifetime %v without tracking lifetimes", age)
+	}
+
+} /*  End of function  TestTrackLifetimes.  */
+
+func TestLaunchedLifetime(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	pid, exited, err := r.launch(ChildSpec{Path: "/bin/sh", Args: []string{"sh", "-c", "sleep 0.3"}})
+	if err != nil {
+		t.Fatalf("failed to launch child: %v", err)
+	}
+
+	select {
+	case event := <-exited:
+		if event.Lifetime < 300*time.Millisecond || event.Lifetime > 5*time.Second {
+			t.Errorf("child %d lived %v, expected 300ms or so", pid, event.Lifetime)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for child %d to be reaped", pid)
+	}
+
+	/*  In the bucket up to a second, none of it adopted.  */
+	eventually(t, "the reap stats", func() bool { return 1 == r.Stats().Reaped })
+	stats := r.Stats()
+	if 1 != stats.Lifetimes[1] || stats.LifetimeSum < 300*time.Millisecond || 0 != stats.AdoptedLifetimeSum {
+		t.Errorf("lifetimes %v summing up to %v, adopted ones to %v", stats.Lifetimes, stats.LifetimeSum, stats.AdoptedLifetimeSum)
+	}
+
+	/*  Children we didn't launch live for an unknown time.  */
+	events, unsubscribe := r.Subscribe(1)
+	defer unsubscribe()
+	if event := reapOf(t, events, spawn(t, "exit 0")); 0 != event.Lifetime {
+		t.Errorf("child not launched lived %v", event.Lifetime)
+	}
+
+} /*  End of function  TestLaunchedLifetime.  */
@@ -0,0 +1,85 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-kit/log/level"
+)
+
+/*  The log levels, least severe first.  */
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// levelFilter Logger passing on the log lines at or above a minimum level
+// that can be changed on the fly (see Config.ControlSocket and
+// Config.DebugSignals). Used for the default logger only, a logger of
+// your own filters as it sees fit.
+type levelFilter struct {
+	next Logger
+	min  atomic.Int32
+	prev atomic.Int32
+}
+
+// Rank of a log level, as per logLevels.
+func levelRank(name string) (int, bool) {
+	for rank, lvl := range logLevels {
+		if lvl == name {
+			return rank, true
+		}
+	}
+
+	return 0, false
+
+} /*  End of function  levelRank.  */
+
+// Log Implement Logger. Lines without a level always pass.
+func (f *levelFilter) Log(keyvals ...interface{}) error {
+	for idx := 0; idx+1 < len(keyvals); idx += 2 {
+		lvl, ok := keyvals[idx+1].(level.Value)
+		if !ok || keyvals[idx] != level.Key() {
+			continue
+		}
+
+		if rank, ok := levelRank(lvl.String()); ok && int32(rank) < f.min.Load() {
+			return nil
+		}
+		break
+	}
+
+	return f.next.Log(keyvals...)
+
+} /*  End of [exported] method  levelFilter.Log.  */
+
+// Set the minimum level by name.
+func (f *levelFilter) set(name string) error {
+	rank, ok := levelRank(name)
+	if !ok {
+		return fmt.Errorf("unknown log level %q, want one of %v", name, logLevels)
+	}
+
+	f.min.Store(int32(rank))
+	return nil
+
+} /*  End of method  levelFilter.set.  */
+
+// Toggle between debug and the level we were at before (info if we
+// started out at debug), returns the name of the new level.
+func (f *levelFilter) toggleDebug() string {
+	if min := f.min.Load(); 0 != min {
+		f.prev.Store(min)
+		f.min.Store(0)
+		return logLevels[0]
+	}
+
+	prev := f.prev.Load()
+	if 0 == prev {
+		prev = 1
+	}
+
+	f.min.Store(prev)
+	return logLevels[prev]
+
+} /*  End of method  levelFilter.toggleDebug.  */
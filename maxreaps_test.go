@@ -0,0 +1,62 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+func TestMaxReaps(t *testing.T) {
+	const max = 3
+
+	r := New(Config{Pid: -1, DisablePid1Check: true, AllowHostReaping: true, MaxReaps: max, Logger: log.NewNopLogger()})
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- r.Run(context.Background())
+	}()
+	<-r.ready
+
+	/*  One more than the reaper is allowed to reap.  */
+	var pids []int
+	for i := 0; i <= max; i++ {
+		pids = append(pids, spawn(t, "exit 0"))
+	}
+
+	select {
+	case err := <-errs:
+		if nil != err {
+			t.Errorf("run returned %v after %d reaps, expected nil", err, max)
+		}
+	case <-time.After(5 * time.Second):
+		r.Stop()
+		t.Fatalf("reaper still running after %d children exited", len(pids))
+	}
+
+	if reason := r.ShutdownReason(); ReasonMaxReaps != reason {
+		t.Errorf("shutdown reason %v, expected %v", reason, ReasonMaxReaps)
+	}
+	if reaped := r.Stats().Reaped; max != reaped {
+		t.Errorf("reaped %d children, expected %d", reaped, max)
+	}
+
+	/*  Whatever the reaper left behind is ours to wait on.  */
+	left := 0
+	for _, pid := range pids {
+		var wstatus syscall.WaitStatus
+		if wpid, _ := syscall.Wait4(pid, &wstatus, 0, nil); pid == wpid {
+			left++
+		}
+	}
+	if 0 == left {
+		t.Errorf("reaper reaped all of %d children, expected at most %d", len(pids), max)
+	}
+
+} /*  End of function  TestMaxReaps.  */
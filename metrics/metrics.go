@@ -0,0 +1,147 @@
+// Package metrics Prometheus metrics for the grim reaper. A module of its
+// own, so that only those who run Prometheus pull in its dependencies.
+//
+// To export a reaper's metrics:
+//
+//	r := reaper.New(config)
+//	prometheus.MustRegister(metrics.NewCollector(r))
+package metrics
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"time"
+
+	reaper "github.com/kakkoyun/go-reaper"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "reaper"
+
+// Build a lifetime histogram from the counts in a reaper's stats buckets,
+// which (unlike prometheus') aren't cumulative.
+func lifetimeHistogram(desc *prometheus.Desc, counts []uint64, sum time.Duration, origin string) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(reaper.LifetimeBuckets))
+
+	total := uint64(0)
+	for idx, count := range counts {
+		total += count
+		if idx < len(reaper.LifetimeBuckets) {
+			buckets[reaper.LifetimeBuckets[idx].Seconds()] = total
+		}
+	}
+
+	return prometheus.MustNewConstHistogram(desc, total, sum.Seconds(), buckets, origin)
+
+} /*  End of function  lifetimeHistogram.  */
+
+// Collector A prometheus.Collector for a reaper's stats: the children
+// reaped (by exit class and by exit code), the children killed by a
+// signal, the SIGCHLDs dropped (and lost, see Config.SweepInterval) and
+// the number of our children that are zombies right now - which a
+// healthy reaper keeps at zero. The zombie gauge is left out where there
+// is no /proc to count them in.
+//
+// The exit codes are those of Stats.ExitCodes ("0", "137", "signal:9"),
+// capped just the same: past the first few dozen distinct ones, any new
+// code is counted as "other".
+//
+// The lifetimes of the reaped children go into a histogram by origin:
+// "launched" for the children the reaper launched itself and "adopted"
+// for the others, which takes Config.TrackLifetimes.
+type Collector struct {
+	reaper *reaper.Reaper
+
+	reaped         *prometheus.Desc
+	exitCodes      *prometheus.Desc
+	signaled       *prometheus.Desc
+	droppedSignals *prometheus.Desc
+	sweepReaped    *prometheus.Desc
+	zombies        *prometheus.Desc
+	lifetime       *prometheus.Desc
+}
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// NewCollector Create a collector for the given reaper's metrics.
+func NewCollector(r *reaper.Reaper) *Collector {
+	return &Collector{
+		reaper: r,
+
+		reaped: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "children", "reaped_total"),
+			"Children reaped, by exit class (exited, failed, signaled, core-dumped).",
+			[]string{"class"}, nil),
+		exitCodes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "exit_code_total"),
+			"Children reaped, by exit code (or signal:N for the ones killed by a signal, other past the cap).",
+			[]string{"code"}, nil),
+		signaled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "children", "signaled_total"),
+			"Children reaped that were killed by a signal.",
+			nil, nil),
+		droppedSignals: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "dropped_signals_total"),
+			"SIGCHLDs that did not wake up the reaper on their own (coalesced, harmless).",
+			nil, nil),
+		sweepReaped: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "sweep_reaped_total"),
+			"Children only caught by the periodic sweep, their SIGCHLD was lost.",
+			nil, nil),
+		zombies: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "zombies"),
+			"Children of ours that are zombies, i.e. dead but not yet reaped.",
+			nil, nil),
+		lifetime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "child", "lifetime_seconds"),
+			"How long the reaped children lived, by origin (launched, adopted).",
+			[]string{"origin"}, nil),
+	}
+
+} /*  End of [exported] function  NewCollector.  */
+
+// Describe Implement prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.reaped
+	ch <- c.exitCodes
+	ch <- c.signaled
+	ch <- c.droppedSignals
+	ch <- c.sweepReaped
+	ch <- c.zombies
+	ch <- c.lifetime
+
+} /*  End of [exported] method  Collector.Describe.  */
+
+// Collect Implement prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.reaper.Stats()
+
+	for outcome, count := range map[reaper.Outcome]uint64{
+		reaper.OutcomeExited:     stats.Exited,
+		reaper.OutcomeFailed:     stats.Failed,
+		reaper.OutcomeSignaled:   stats.Signaled,
+		reaper.OutcomeCoreDumped: stats.CoreDumped,
+	} {
+		ch <- prometheus.MustNewConstMetric(c.reaped, prometheus.CounterValue, float64(count), outcome.String())
+	}
+
+	for code, count := range stats.ExitCodes {
+		ch <- prometheus.MustNewConstMetric(c.exitCodes, prometheus.CounterValue, float64(count), code)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.signaled, prometheus.CounterValue, float64(stats.Signaled+stats.CoreDumped))
+	ch <- prometheus.MustNewConstMetric(c.droppedSignals, prometheus.CounterValue, float64(stats.DroppedSignals))
+	ch <- prometheus.MustNewConstMetric(c.sweepReaped, prometheus.CounterValue, float64(stats.SweepReaped))
+	ch <- lifetimeHistogram(c.lifetime, stats.Lifetimes[:], stats.LifetimeSum, "launched")
+	ch <- lifetimeHistogram(c.lifetime, stats.AdoptedLifetimes[:], stats.AdoptedLifetimeSum, "adopted")
+
+	if zombies, err := reaper.CountZombies(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.zombies, prometheus.GaugeValue, float64(zombies))
+	}
+
+} /*  End of [exported] method  Collector.Collect.  */
@@ -0,0 +1,85 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package metrics
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	reaper "github.com/kakkoyun/go-reaper"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The value of a counter gathered from the registry, by metric name and
+// label value.
+func counter(t *testing.T, registry *prometheus.Registry, name, label string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if name != family.GetName() {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if label == pair.GetValue() {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return 0
+
+} /*  End of function  counter.  */
+
+func TestCollector(t *testing.T) {
+	r := reaper.New(reaper.Config{Pid: -1, DisablePid1Check: true, AllowHostReaping: true, Logger: log.NewNopLogger()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+	t.Cleanup(r.Stop)
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(NewCollector(r))
+
+	scripts := []string{"exit 0", "exit 3", "exit 3", "kill -KILL $$"}
+	for _, script := range scripts {
+		if err := exec.Command("/bin/sh", "-c", script).Start(); err != nil {
+			t.Fatalf("failed to start %q: %v", script, err)
+		}
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); r.Stats().Reaped < uint64(len(scripts)); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the reaps")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, test := range []struct {
+		name, label string
+		expected    float64
+	}{
+		{"reaper_exit_code_total", "0", 1},
+		{"reaper_exit_code_total", "3", 2},
+		{"reaper_exit_code_total", "signal:9", 1},
+		{"reaper_children_reaped_total", "failed", 2},
+		{"reaper_children_reaped_total", "signaled", 1},
+	} {
+		if value := counter(t, registry, test.name, test.label); test.expected != value {
+			t.Errorf("%s{%s}: %v, expected %v", test.name, test.label, value, test.expected)
+		}
+	}
+
+} /*  End of function  TestCollector.  */
@@ -0,0 +1,147 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+/*  How often ReapAll checks whether the registered children are done.  */
+const reapAllPollInterval = 100 * time.Millisecond
+
+// Check if there are children still to wait for before all are reaped:
+// registered ones (up to their owners) or ones we launched or claimed.
+func (r *Reaper) pending() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.claimed) > 0 || len(r.waiters) > 0
+
+} /*  End of method  Reaper.pending.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// ReapOnce Reap whatever children are waitable right now, in a single
+// non-blocking (WNOHANG) sweep, and return how many were reaped along
+// with their reap events. Meant for programs that run their own loop (or
+// a cron-like job) rather than a background reaper: nothing is left
+// running once it returns.
+//
+// The config is checked just like Run does, so the pid 1 checks apply
+// (see DisablePid1Check) and ErrAlreadyRunning is returned while a
+// reaper is running in this process. The callbacks are run before it
+// returns and the event sinks (and webhook) get the events too. Stopped
+// and continued children are reported to OnStopped and OnContinued but
+// not returned.
+func ReapOnce(ctx context.Context, config Config) (n int, events []ReapEvent, err error) {
+	config.Options |= wNOHANG
+	r := New(config)
+
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	if err := r.preflight(); err != nil {
+		return 0, nil, err
+	}
+
+	if err := acquire(r); err != nil {
+		return 0, nil, err
+	}
+	defer release(r)
+
+	r.mu.Lock()
+	r.started, r.collect = true, true
+	r.mu.Unlock()
+	defer r.finish()
+
+	r.startSinks()
+	defer r.stopSinks()
+
+	r.startWebhook()
+	defer r.stopWebhook()
+
+	n = r.sweep(ctx, r.callbackContext(ctx), nil)
+	level.Debug(r.config.Logger).Log("msg", "reaped once", "reaped", n)
+
+	r.mu.Lock()
+	events = r.collected
+	r.mu.Unlock()
+
+	return n, events, nil
+
+} /*  End of [exported] function  ReapOnce.  */
+
+// ReapAll Reap children until there are none left (or the context is
+// done), blocking on the ones that are still running. Meant for the very
+// end of a program, right before it exits, so that no zombie is left
+// behind for the kernel to re-parent. Registered children (see Register)
+// are left to their owners, but waited for all the same: it only returns
+// once they are unregistered, and the children launched or claimed (see
+// Supervise and Claim) reaped. Returns the number of children reaped,
+// and the context's error if it was done before they all were.
+//
+// The reaper must not be running (see Run): ErrAlreadyRunning is
+// returned while it, or any other reaper in this process, is.
+func (r *Reaper) ReapAll(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	started := r.started
+	r.mu.Unlock()
+
+	if started {
+		select {
+		case <-r.done:
+		default:
+			return 0, ErrAlreadyRunning
+		}
+	}
+
+	if err := r.preflight(); err != nil {
+		return 0, err
+	}
+
+	if err := acquire(r); err != nil {
+		return 0, err
+	}
+	defer release(r)
+
+	/*  Before the first wait, so that no exit goes unnoticed.  */
+	wakeups := make(chan os.Signal, 1)
+	signal.Notify(wakeups, sigCHLD)
+	defer signal.Stop(wakeups)
+
+	poll := time.NewTicker(reapAllPollInterval)
+	defer poll.Stop()
+
+	/*  Without WNOHANG, the sweep waits on the children still alive.  */
+	cbctx := r.callbackContext(ctx)
+	opts := r.config.Options &^ wNOHANG
+	n := 0
+	for {
+		n += r.sweepWith(ctx, cbctx, wakeups, opts)
+		if ctx.Err() != nil || !r.pending() {
+			break
+		}
+
+		/*  Nobody tells when the registered ones are done, look again.  */
+		select {
+		case <-ctx.Done():
+		case <-wakeups:
+		case <-poll.C:
+		}
+	}
+	level.Debug(r.config.Logger).Log("msg", "reaped all", "reaped", n)
+
+	return n, ctx.Err()
+
+} /*  End of [exported] method  Reaper.ReapAll.  */
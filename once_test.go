@@ -0,0 +1,99 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// A reaper for ReapAll, not running.
+func newReapAll() *Reaper {
+	return New(Config{
+		Pid:              -1,
+		DisablePid1Check: true,
+		AllowHostReaping: true,
+		Logger:           log.NewNopLogger(),
+	})
+
+} /*  End of function  newReapAll.  */
+
+func TestReapAll(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skipf("no /proc to reap around registered children with: %v", err)
+	}
+
+	r := newReapAll()
+
+	/*  Someone else's to wait on, for a while.  */
+	cmd := exec.Command("/bin/sh", "-c", "sleep 0.3; exit 5")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start the registered child: %v", err)
+	}
+	r.Register(cmd.Process.Pid)
+	waited := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		r.Unregister(cmd.Process.Pid)
+		waited <- err
+	}()
+
+	/*  Handed over to the reaper, also for a while.  */
+	claimed := spawn(t, "sleep 0.2; exit 4")
+	results, release := r.Claim(claimed)
+	defer release()
+
+	spawn(t, "exit 0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if n, err := r.ReapAll(ctx); err != nil || n < 2 {
+		t.Errorf("reaped %d (%v), expected the claimed and the other child", n, err)
+	}
+
+	select {
+	case err := <-waited:
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || 5 != exitErr.ExitCode() {
+			t.Errorf("registered child's owner got %v, expected its exit code", err)
+		}
+	default:
+		t.Errorf("returned before the registered child was unregistered")
+	}
+
+	select {
+	case result := <-results:
+		if result.Err != nil || 4 != ExitCode(result.Event.Status) {
+			t.Errorf("claimed child reaped with %#x (%v), expected exit code 4", uint32(result.Event.Status), result.Err)
+		}
+	default:
+		t.Errorf("returned before the claimed child was reaped")
+	}
+
+} /*  End of function  TestReapAll.  */
+
+func TestReapAllCancelled(t *testing.T) {
+	r := newReapAll()
+
+	/*  Never unregistered.  */
+	pid := spawn(t, "exec sleep 10")
+	r.Register(pid)
+	defer killChild(pid)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := r.ReapAll(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("reaping all with a child registered for good returned %v, expected the deadline", err)
+	}
+
+} /*  End of function  TestReapAllCancelled.  */
@@ -0,0 +1,128 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Option Sets up some part of a reaper's configuration, see NewWithOptions.
+// Options apply in order, a later one wins over an earlier one.
+type Option func(config *Config)
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// NewWithOptions Create a reaper configured by the given options. Unlike
+// the zero Config, it reaps any child (pid -1) out of the box - so with
+// no options at all, this is the reaper Reap runs.
+func NewWithOptions(opts ...Option) *Reaper {
+	config := Config{Pid: -1}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return New(config)
+
+} /*  End of [exported] function  NewWithOptions.  */
+
+// WithConfig Start off with the given config, for the fields that don't
+// have an option of their own. Options before it are overridden.
+func WithConfig(base Config) Option {
+	return func(config *Config) {
+		*config = base
+	}
+
+} /*  End of [exported] function  WithConfig.  */
+
+// WithPid Wait on the given pid, as per wait4(2) (see Config.Pid).
+func WithPid(pid int) Option {
+	return func(config *Config) {
+		config.Pid = pid
+	}
+
+} /*  End of [exported] function  WithPid.  */
+
+// WithWaitOptions Pass these options to wait4(2), e.g. WNOHANG (see
+// Config.Options).
+func WithWaitOptions(options int) Option {
+	return func(config *Config) {
+		config.Options = options
+	}
+
+} /*  End of [exported] function  WithWaitOptions.  */
+
+// WithLogger Log to the given logger.
+func WithLogger(logger Logger) Option {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+
+} /*  End of [exported] function  WithLogger.  */
+
+// WithSlog Log to the given *slog.Logger (see Config.Slog).
+func WithSlog(logger *slog.Logger) Option {
+	return func(config *Config) {
+		config.Slog = logger
+	}
+
+} /*  End of [exported] function  WithSlog.  */
+
+// WithDebug Log at debug level (with the default logger).
+func WithDebug() Option {
+	return func(config *Config) {
+		config.Debug = true
+	}
+
+} /*  End of [exported] function  WithDebug.  */
+
+// WithoutPid1Check Reap even if we aren't pid 1 (see
+// Config.DisablePid1Check). Handle with care.
+func WithoutPid1Check() Option {
+	return func(config *Config) {
+		config.DisablePid1Check = true
+	}
+
+} /*  End of [exported] function  WithoutPid1Check.  */
+
+// WithSubreaper Become a child subreaper rather than having to be pid 1
+// (see Config.EnableSubreaper).
+func WithSubreaper() Option {
+	return func(config *Config) {
+		config.EnableSubreaper = true
+	}
+
+} /*  End of [exported] function  WithSubreaper.  */
+
+// WithReapCallback Invoke the given callback for every reaped child (see
+// Config.ReapCallback).
+func WithReapCallback(callback func(ctx context.Context, event ReapEvent)) Option {
+	return func(config *Config) {
+		config.ReapCallback = callback
+	}
+
+} /*  End of [exported] function  WithReapCallback.  */
+
+// WithGracePeriod Shut the remaining children down gracefully when the
+// reaper stops (see Config.GracePeriod).
+func WithGracePeriod(grace time.Duration) Option {
+	return func(config *Config) {
+		config.GracePeriod = grace
+	}
+
+} /*  End of [exported] function  WithGracePeriod.  */
+
+// WithProcessGroup Only reap the children in the given process group
+// (see Config.TargetGroup and NewProcessGroup).
+func WithProcessGroup(pgid int) Option {
+	return func(config *Config) {
+		config.TargetGroup = pgid
+	}
+
+} /*  End of [exported] function  WithProcessGroup.  */
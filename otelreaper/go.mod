@@ -0,0 +1,19 @@
+module github.com/kakkoyun/go-reaper/otelreaper
+
+go 1.25.0
+
+require (
+	github.com/kakkoyun/go-reaper v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/log v0.22.0
+	go.opentelemetry.io/otel/metric v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-kit/log v0.2.0 // indirect
+	github.com/go-logfmt/logfmt v0.5.1 // indirect
+)
+
+replace github.com/kakkoyun/go-reaper => ../
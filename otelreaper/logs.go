@@ -0,0 +1,131 @@
+// Package otelreaper OpenTelemetry bridges for the grim reaper. A module
+// of its own, so that only those who use OpenTelemetry pull in its
+// dependencies.
+//
+// To ship reap events as OpenTelemetry log records:
+//
+//	logger := global.GetLoggerProvider().Logger("reaper")
+//
+//	config := reaper.Config{
+//		ReapCallback: otelreaper.LogCallback(logger),
+//	}
+//
+// And to record reap counts and child lifetimes as metrics:
+//
+//	metrics, err := otelreaper.NewMetrics(otel.Meter("reaper"))
+//	...
+//	config.ReapCallback = metrics.Callback()
+package otelreaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+
+	reaper "github.com/kakkoyun/go-reaper"
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// Severity of a reap event. Clean exits are routine and logged at debug,
+// non-zero exits and kills at warn (info if the reaper sent the signal
+// itself) and core dumps at error. A child that did not exit the way it
+// was expected to is always an error.
+func severity(event reaper.ReapEvent) otellog.Severity {
+	if reaper.EventReaped != event.Type {
+		return otellog.SeverityDebug
+	}
+
+	if event.Unexpected {
+		return otellog.SeverityError
+	}
+
+	switch event.Outcome() {
+	case reaper.OutcomeFailed:
+		return otellog.SeverityWarn
+	case reaper.OutcomeSignaled:
+		if event.SelfSignaled {
+			return otellog.SeverityInfo
+		}
+		return otellog.SeverityWarn
+	case reaper.OutcomeCoreDumped:
+		return otellog.SeverityError
+	}
+
+	return otellog.SeverityDebug
+
+} /*  End of function  severity.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Record Convert a reap event to an OpenTelemetry log record.
+func Record(event reaper.ReapEvent) otellog.Record {
+	var record otellog.Record
+
+	sev := severity(event)
+	record.SetTimestamp(event.Time)
+	record.SetSeverity(sev)
+	record.SetSeverityText(sev.String())
+
+	record.AddAttributes(
+		attribute.Int("pid", event.Pid),
+		attribute.Int64("seq", int64(event.Seq)),
+		attribute.String("event.type", event.Type.String()),
+		attribute.Bool("orphan", event.Orphan),
+	)
+
+	switch event.Type {
+	case reaper.EventContinued:
+		record.SetBody(attribute.StringValue("child continued"))
+		return record
+	case reaper.EventAdopted:
+		record.SetBody(attribute.StringValue("child adopted"))
+		record.AddAttributes(
+			attribute.String("comm", event.Comm),
+			attribute.StringSlice("cmdline", event.Cmdline),
+		)
+		return record
+	case reaper.EventStopped:
+		record.SetBody(attribute.StringValue("child stopped"))
+		record.AddAttributes(attribute.String("signal", event.Status.StopSignal().String()))
+		return record
+	}
+
+	record.SetBody(attribute.StringValue("child reaped"))
+	record.AddAttributes(
+		attribute.String("outcome", event.Outcome().String()),
+		attribute.Bool("self_signaled", event.SelfSignaled),
+		attribute.Bool("unexpected", event.Unexpected),
+	)
+
+	if event.Lifetime > 0 {
+		record.AddAttributes(attribute.Float64("lifetime_seconds", event.Lifetime.Seconds()))
+	}
+
+	if event.Status.Signaled() {
+		record.AddAttributes(
+			attribute.String("signal", event.Status.Signal().String()),
+			attribute.Bool("core_dumped", event.Status.CoreDump()),
+		)
+	} else {
+		record.AddAttributes(attribute.Int("exit_code", event.Status.ExitStatus()))
+	}
+
+	return record
+
+} /*  End of [exported] function  Record.  */
+
+// LogCallback Returns a Config.ReapCallback that emits every reap event
+// to logger. Events are emitted with the callback's context, so any span
+// in Config.CallbackContext is picked up by the SDK.
+func LogCallback(logger otellog.Logger) func(context.Context, reaper.ReapEvent) {
+	return func(ctx context.Context, event reaper.ReapEvent) {
+		logger.Emit(ctx, Record(event))
+	}
+
+} /*  End of [exported] function  LogCallback.  */
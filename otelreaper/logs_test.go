@@ -0,0 +1,127 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package otelreaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	reaper "github.com/kakkoyun/go-reaper"
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// A logger that holds on to the records emitted, in lieu of an SDK.
+type fakeLogger struct {
+	embedded.Logger
+
+	mu      sync.Mutex
+	records []otellog.Record
+}
+
+func (l *fakeLogger) Emit(ctx context.Context, record otellog.Record) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.records = append(l.records, record)
+
+} /*  End of method  fakeLogger.Emit.  */
+
+func (l *fakeLogger) Enabled(context.Context, otellog.EnabledParameters) bool {
+	return true
+
+} /*  End of method  fakeLogger.Enabled.  */
+
+// The attributes of a record, by key.
+func attributes(record otellog.Record) map[string]attribute.Value {
+	attrs := make(map[string]attribute.Value)
+	record.WalkAttributes(func(kv attribute.KeyValue) bool {
+		attrs[string(kv.Key)] = kv.Value
+		return true
+	})
+
+	return attrs
+
+} /*  End of function  attributes.  */
+
+// Wait statuses as wait4(2) reports them.
+func exited(code int) reaper.WaitStatus {
+	return reaper.WaitStatus(code << 8)
+
+} /*  End of function  exited.  */
+
+func killed(sig syscall.Signal, core bool) reaper.WaitStatus {
+	if core {
+		return reaper.WaitStatus(int(sig) | 0x80)
+	}
+
+	return reaper.WaitStatus(sig)
+
+} /*  End of function  killed.  */
+
+func TestSeverity(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		event    reaper.ReapEvent
+		expected otellog.Severity
+	}{
+		{"clean exit", reaper.ReapEvent{Status: exited(0)}, otellog.SeverityDebug},
+		{"failed", reaper.ReapEvent{Status: exited(3)}, otellog.SeverityWarn},
+		{"killed", reaper.ReapEvent{Status: killed(syscall.SIGKILL, false)}, otellog.SeverityWarn},
+		{"terminated", reaper.ReapEvent{Status: killed(syscall.SIGTERM, false), SelfSignaled: true}, otellog.SeverityInfo},
+		{"core dumped", reaper.ReapEvent{Status: killed(syscall.SIGSEGV, true)}, otellog.SeverityError},
+		{"unexpected", reaper.ReapEvent{Status: exited(0), Unexpected: true}, otellog.SeverityError},
+		{"stopped", reaper.ReapEvent{Type: reaper.EventStopped, Status: killed(syscall.SIGKILL, false)}, otellog.SeverityDebug},
+	} {
+		record := Record(test.event)
+		if sev := record.Severity(); test.expected != sev {
+			t.Errorf("%s: severity %v, expected %v", test.name, sev, test.expected)
+		}
+	}
+
+} /*  End of function  TestSeverity.  */
+
+func TestLogCallback(t *testing.T) {
+	logger := &fakeLogger{}
+	callback := LogCallback(logger)
+
+	now := time.Now()
+	callback(context.Background(), reaper.ReapEvent{Pid: 42, Seq: 7, Time: now, Status: exited(3), Lifetime: 2 * time.Second})
+	callback(context.Background(), reaper.ReapEvent{Pid: 43, Seq: 8, Time: now, Status: killed(syscall.SIGSEGV, true), Orphan: true})
+
+	if 2 != len(logger.records) {
+		t.Fatalf("emitted %d records, expected 2", len(logger.records))
+	}
+
+	failed := logger.records[0]
+	if !failed.Timestamp().Equal(now) || "child reaped" != failed.Body().AsString() || "WARN" != failed.SeverityText() {
+		t.Errorf("record at %v with body %v, severity %q", failed.Timestamp(), failed.Body(), failed.SeverityText())
+	}
+
+	attrs := attributes(failed)
+	if 42 != attrs["pid"].AsInt64() || 7 != attrs["seq"].AsInt64() || 3 != attrs["exit_code"].AsInt64() ||
+		"failed" != attrs["outcome"].AsString() || 2 != attrs["lifetime_seconds"].AsFloat64() {
+		t.Errorf("failed child's attributes %v", attrs)
+	}
+	if _, ok := attrs["signal"]; ok {
+		t.Errorf("failed child has a signal attribute: %v", attrs["signal"])
+	}
+
+	attrs = attributes(logger.records[1])
+	if syscall.SIGSEGV.String() != attrs["signal"].AsString() || !attrs["core_dumped"].AsBool() || !attrs["orphan"].AsBool() {
+		t.Errorf("core dumped child's attributes %v", attrs)
+	}
+	if _, ok := attrs["exit_code"]; ok {
+		t.Errorf("killed child has an exit code attribute: %v", attrs["exit_code"])
+	}
+	if _, ok := attrs["lifetime_seconds"]; ok {
+		t.Errorf("child of unknown lifetime has a lifetime attribute: %v", attrs["lifetime_seconds"])
+	}
+
+} /*  End of function  TestLogCallback.  */
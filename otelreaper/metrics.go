@@ -0,0 +1,80 @@
+package otelreaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+
+	reaper "github.com/kakkoyun/go-reaper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Metrics OpenTelemetry instruments for reap events: a counter of the
+// children reaped (by outcome) and a histogram of their lifetimes, for
+// the children whose lifetime the reaper knows (see ReapEvent).
+type Metrics struct {
+	reaped   metric.Int64Counter
+	lifetime metric.Float64Histogram
+}
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// NewMetrics Create the reaper's instruments with the given meter.
+func NewMetrics(meter metric.Meter) (*Metrics, error) {
+	reaped, err := meter.Int64Counter("reaper.children.reaped",
+		metric.WithDescription("Children reaped, by outcome."),
+		metric.WithUnit("{child}"))
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime, err := meter.Float64Histogram("reaper.child.lifetime",
+		metric.WithDescription("How long reaped children lived, from launch to reap."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{reaped: reaped, lifetime: lifetime}, nil
+
+} /*  End of [exported] function  NewMetrics.  */
+
+// Record Record a reap event. If ctx carries a recording span, the reap
+// is added to it as a span event as well.
+func (m *Metrics) Record(ctx context.Context, event reaper.ReapEvent) {
+	if reaper.EventReaped != event.Type {
+		return
+	}
+
+	outcome := attribute.String("outcome", event.Outcome().String())
+	m.reaped.Add(ctx, 1, metric.WithAttributes(outcome))
+	if event.Lifetime > 0 {
+		m.lifetime.Record(ctx, event.Lifetime.Seconds(), metric.WithAttributes(outcome))
+	}
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.AddEvent("child reaped", trace.WithTimestamp(event.Time), trace.WithAttributes(
+			attribute.Int("pid", event.Pid),
+			attribute.Int64("seq", int64(event.Seq)),
+			outcome,
+			attribute.Int("exit_code", event.ExitCode()),
+			attribute.Bool("unexpected", event.Unexpected),
+		))
+	}
+
+} /*  End of [exported] method  Metrics.Record.  */
+
+// Callback Returns a Config.ReapCallback that records every reap event,
+// see Record. Set Config.CallbackContext to a context carrying a span
+// for the reaps to show up on it.
+func (m *Metrics) Callback() func(context.Context, reaper.ReapEvent) {
+	return m.Record
+
+} /*  End of [exported] method  Metrics.Callback.  */
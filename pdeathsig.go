@@ -0,0 +1,30 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"os/exec"
+	"syscall"
+)
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// SetParentDeathSignal Have the command's child sent sig (PR_SET_PDEATHSIG)
+// should its parent die first, so that workers are told to go away rather
+// than silently orphaned to whatever reaps them next. Strictly speaking
+// the signal is sent once the thread that started the child goes away:
+// don't start it from a goroutine that is locked to its thread (see
+// runtime.LockOSThread) and then exits. Call this before cmd.Start. A
+// no-op anywhere but on linux and FreeBSD.
+func SetParentDeathSignal(cmd *exec.Cmd, sig Signal) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	setPdeathsig(cmd.SysProcAttr, sig, true)
+
+} /*  End of [exported] function  SetParentDeathSignal.  */
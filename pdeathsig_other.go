@@ -0,0 +1,13 @@
+//go:build !linux && !freebsd
+// +build !linux,!freebsd
+
+package reaper
+
+//  Prefer #include style directives.
+import "syscall"
+
+/*  Parent death signals are a linux (and FreeBSD) thing.  */
+const pdeathsigSupported = false
+
+func setPdeathsig(attr *syscall.SysProcAttr, sig Signal, force bool) {
+} /*  End of function  setPdeathsig.  */
@@ -0,0 +1,19 @@
+//go:build linux || freebsd
+// +build linux freebsd
+
+package reaper
+
+//  Prefer #include style directives.
+import "syscall"
+
+/*  Children can be sent a signal when their parent dies.  */
+const pdeathsigSupported = true
+
+// Ask for sig to be sent to the child once its parent dies. A signal
+// asked for already is left alone, unless force is set.
+func setPdeathsig(attr *syscall.SysProcAttr, sig syscall.Signal, force bool) {
+	if force || 0 == attr.Pdeathsig {
+		attr.Pdeathsig = sig
+	}
+
+} /*  End of function  setPdeathsig.  */
@@ -0,0 +1,25 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"os/exec"
+)
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// NewProcessGroup Have the command start in a new process group of its
+// own, with the command's pid as the process group id - so once it has
+// been started, cmd.Process.Pid is the group to hand to a reaper of its
+// own (see Config.TargetGroup and WithProcessGroup). Whatever the command
+// spawns in turn stays in that group, unless it moves elsewhere itself.
+// Call this before cmd.Start. A no-op where there are no process groups.
+func NewProcessGroup(cmd *exec.Cmd) {
+	isolate(cmd)
+
+} /*  End of [exported] function  NewProcessGroup.  */
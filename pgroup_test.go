@@ -0,0 +1,64 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+)
+
+// Start a child in a process group of its own, returns its pid - which is
+// the group's id.
+func spawnGroup(t *testing.T, script string) int {
+	t.Helper()
+
+	cmd := exec.Command("/bin/sh", "-c", script)
+	NewProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start %q: %v", script, err)
+	}
+
+	return cmd.Process.Pid
+
+} /*  End of function  spawnGroup.  */
+
+func TestTargetGroup(t *testing.T) {
+	first := spawnGroup(t, "sleep 0.2; exit 3")
+	second := spawnGroup(t, "sleep 0.2; exit 4")
+
+	/*  Two reapers side by side, one per group.  */
+	reapers := []*Reaper{
+		startTestReaper(t, Config{TargetGroup: first}),
+		startTestReaper(t, Config{TargetGroup: second}),
+	}
+
+	var subscriptions []<-chan ReapEvent
+	for _, r := range reapers {
+		events, unsubscribe := r.Subscribe(16)
+		defer unsubscribe()
+		subscriptions = append(subscriptions, events)
+	}
+
+	/*  Neither of them is to reap a child in our own group.  */
+	outsider := spawn(t, "sleep 0.2; exit 5")
+
+	if event := reapOf(t, subscriptions[0], first); 3 != event.Status.ExitStatus() {
+		t.Errorf("first group's child exited with %d, expected 3", event.Status.ExitStatus())
+	}
+	if event := reapOf(t, subscriptions[1], second); 4 != event.Status.ExitStatus() {
+		t.Errorf("second group's child exited with %d, expected 4", event.Status.ExitStatus())
+	}
+
+	var wstatus syscall.WaitStatus
+	if wpid, err := syscall.Wait4(outsider, &wstatus, 0, nil); outsider != wpid || 5 != wstatus.ExitStatus() {
+		t.Errorf("outsider %d waited on with %v (status %v), expected it left for us", outsider, err, wstatus)
+	}
+
+	for _, r := range reapers {
+		eventually(t, "the reap stats", func() bool { return 1 == r.Stats().Reaped })
+	}
+
+} /*  End of function  TestTargetGroup.  */
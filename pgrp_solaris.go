@@ -0,0 +1,18 @@
+//go:build solaris
+// +build solaris
+
+package reaper
+
+// Our process group - unknown, there's no getpgrp(2) in syscall here. It
+// is only needed to match /proc/<pid>/stat records, which solaris (and
+// illumos) don't have in the linux format anyway.
+func getpgrp() int {
+	return -1
+
+} /*  End of function  getpgrp.  */
+
+// Process group of a process - unknown just as well.
+func getpgid(pid int) (int, error) {
+	return 0, ErrUnsupportedPlatform
+
+} /*  End of function  getpgid.  */
@@ -0,0 +1,19 @@
+//go:build darwin || dragonfly || freebsd || hurd || ios || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd hurd ios linux netbsd openbsd
+
+package reaper
+
+//  Prefer #include style directives.
+import "syscall"
+
+// Our process group.
+func getpgrp() int {
+	return syscall.Getpgrp()
+
+} /*  End of function  getpgrp.  */
+
+// Process group of a process.
+func getpgid(pid int) (int, error) {
+	return syscall.Getpgid(pid)
+
+} /*  End of function  getpgid.  */
@@ -0,0 +1,109 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+
+	"github.com/go-kit/log/level"
+)
+
+// Take hold of a pidfd for a child we just launched and have a goroutine
+// poll it for the child's exit. The child is claimed (see Register), so
+// that the sweeps leave it for the pidfd. Caller holds the lock - the
+// child can't have been reaped yet, so the pidfd is for the right one.
+func (r *Reaper) watchPidfdLocked(pid int) {
+	select {
+	case <-r.done:
+		return
+	default:
+	}
+
+	pidfd, err := pidfdOpen(pid)
+	if err != nil {
+		level.Debug(r.config.Logger).Log("msg", "no pidfd, waiting via SIGCHLD", "pid", pid, "err", err)
+		return
+	}
+
+	r.pidfds[pid] = pidfd
+	r.claimed[pid] = struct{}{}
+
+	go r.pollPidfd(pid, pidfd)
+
+} /*  End of method  Reaper.watchPidfdLocked.  */
+
+// Wait for a pidfd to poll readable and hand the child's pid over to the
+// reap loop. The reaper stopping (see finish) breaks the wait.
+func (r *Reaper) pollPidfd(pid int, pidfd *os.File) {
+	rc, err := pidfd.SyscallConn()
+	if err == nil {
+		err = rc.Read(pidfdExited)
+	}
+
+	if err != nil {
+		select {
+		case <-r.done:
+		default:
+			/*  Not pollable after all, leave the child to the sweeps.  */
+			level.Warn(r.config.Logger).Log("msg", "can't poll pidfd, waiting via SIGCHLD", "pid", pid, "err", err)
+		}
+		r.dropPidfd(pid)
+		return
+	}
+
+	select {
+	case r.exits <- pid:
+	case <-r.done:
+		r.dropPidfd(pid)
+	}
+
+} /*  End of method  Reaper.pollPidfd.  */
+
+// Forget about a child's pidfd, unclaiming the child.
+func (r *Reaper) dropPidfd(pid int) {
+	r.mu.Lock()
+	pidfd, ok := r.pidfds[pid]
+	delete(r.pidfds, pid)
+	if ok {
+		delete(r.claimed, pid)
+	}
+	r.mu.Unlock()
+
+	if ok {
+		pidfd.Close()
+	}
+
+} /*  End of method  Reaper.dropPidfd.  */
+
+// Reap a child whose pidfd polled readable, unless Config.MaxReaps have
+// been reaped already. The wait is done under the lock, just like the
+// sweeps do theirs.
+func (r *Reaper) reapPidfd(cbctx context.Context, pid int) {
+	if r.limitReached() {
+		/*  Leave it be, we are done.  */
+		return
+	}
+
+	r.mu.Lock()
+	pidfd, ok := r.pidfds[pid]
+	if !ok {
+		r.mu.Unlock()
+		return
+	}
+
+	wstatus, rusage, reaped, err := pidfdWait(pidfd)
+	r.mu.Unlock()
+
+	r.dropPidfd(pid)
+
+	switch {
+	case err != nil:
+		/*  Someone got to it first (e.g. the kernel auto-reaping).  */
+		level.Debug(r.config.Logger).Log("msg", "pidfd wait failed", "pid", pid, "err", err)
+	case reaped:
+		r.reaped(cbctx, ReapEvent{Pid: pid, Status: wstatus, Usage: usageOf(rusage)})
+	}
+
+} /*  End of method  Reaper.reapPidfd.  */
@@ -0,0 +1,129 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le
+// +build linux,!mips,!mipsle,!mips64,!mips64le
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+/*  Not exported by the syscall package (same on all but mips).  */
+const (
+	sysPidfdSendSignal = 424
+	sysPidfdOpen       = 434
+
+	pPIDFD = 3 /*  waitid(2) id type.  */
+
+	cldExited = 1
+	cldKilled = 2
+	cldDumped = 3
+)
+
+// Open a pidfd for a child, non-blocking so that it ends up on the
+// runtime poller - a pidfd polls readable once the process exited.
+func pidfdOpen(pid int) (*os.File, error) {
+	fd, _, errno := syscall.Syscall(sysPidfdOpen, uintptr(pid), 0, 0)
+	if 0 != errno {
+		return nil, os.NewSyscallError("pidfd_open", errno)
+	}
+
+	if err := syscall.SetNonblock(int(fd), true); err != nil {
+		syscall.Close(int(fd))
+		return nil, err
+	}
+
+	return os.NewFile(fd, "pidfd"), nil
+
+} /*  End of function  pidfdOpen.  */
+
+// waitid(2) on the process a pidfd refers to.
+func pidfdWaitid(fd uintptr, info *siginfo, opts int, rusage *syscall.Rusage) error {
+	for {
+		_, _, errno := syscall.Syscall6(syscall.SYS_WAITID, pPIDFD, fd, uintptr(unsafe.Pointer(info)), uintptr(opts|syscall.WEXITED), uintptr(unsafe.Pointer(rusage)), 0)
+		if syscall.EINTR == errno {
+			continue
+		}
+		if 0 != errno {
+			return errno
+		}
+
+		return nil
+	}
+
+} /*  End of function  pidfdWaitid.  */
+
+// Check if the process a pidfd refers to exited (or is gone already),
+// without reaping it.
+func pidfdExited(fd uintptr) bool {
+	var info siginfo
+	if err := pidfdWaitid(fd, &info, syscall.WNOHANG|syscall.WNOWAIT, nil); err != nil {
+		return true
+	}
+
+	return 0 != info.Pid
+
+} /*  End of function  pidfdExited.  */
+
+// Reap the process a pidfd refers to, if it exited. Returns false (and
+// no error) if it is still running.
+func pidfdWait(pidfd *os.File) (syscall.WaitStatus, *syscall.Rusage, bool, error) {
+	var (
+		info    siginfo
+		rusage  syscall.Rusage
+		waitErr error
+	)
+
+	rc, err := pidfd.SyscallConn()
+	if err != nil {
+		return 0, nil, false, err
+	}
+
+	err = rc.Control(func(fd uintptr) {
+		waitErr = pidfdWaitid(fd, &info, syscall.WNOHANG, &rusage)
+	})
+	if err == nil {
+		err = waitErr
+	}
+	if err != nil || 0 == info.Pid {
+		return 0, nil, false, err
+	}
+
+	/*  Back to the wait4(2) style status the rest of us deal in.  */
+	var wstatus syscall.WaitStatus
+	switch info.Code {
+	case cldExited:
+		wstatus = syscall.WaitStatus(info.Status&0xff) << 8
+	case cldKilled:
+		wstatus = syscall.WaitStatus(info.Status & 0x7f)
+	case cldDumped:
+		wstatus = syscall.WaitStatus(info.Status&0x7f) | 0x80
+	}
+
+	return wstatus, &rusage, true, nil
+
+} /*  End of function  pidfdWait.  */
+
+// Send a signal via a pidfd.
+func pidfdSignal(pidfd *os.File, sig syscall.Signal) error {
+	rc, err := pidfd.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var errno syscall.Errno
+	err = rc.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall6(sysPidfdSendSignal, fd, uintptr(sig), 0, 0, 0, 0)
+	})
+	if err != nil {
+		return err
+	}
+	if 0 != errno {
+		return os.NewSyscallError("pidfd_send_signal", errno)
+	}
+
+	return nil
+
+} /*  End of function  pidfdSignal.  */
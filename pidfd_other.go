@@ -0,0 +1,30 @@
+//go:build !linux || mips || mipsle || mips64 || mips64le
+// +build !linux mips mipsle mips64 mips64le
+
+package reaper
+
+//  Prefer #include style directives.
+import "os"
+
+// pidfds are only wired up for linux (and not for mips, which numbers
+// its syscalls differently).
+func pidfdOpen(pid int) (*os.File, error) {
+	return nil, ErrUnsupportedPlatform
+
+} /*  End of function  pidfdOpen.  */
+
+func pidfdExited(fd uintptr) bool {
+	return true
+
+} /*  End of function  pidfdExited.  */
+
+func pidfdWait(pidfd *os.File) (WaitStatus, *sysUsage, bool, error) {
+	var wstatus WaitStatus
+	return wstatus, nil, false, ErrUnsupportedPlatform
+
+} /*  End of function  pidfdWait.  */
+
+func pidfdSignal(pidfd *os.File, sig Signal) error {
+	return ErrUnsupportedPlatform
+
+} /*  End of function  pidfdSignal.  */
@@ -0,0 +1,55 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+func TestPidfdMaxReaps(t *testing.T) {
+	r := New(Config{Pid: -1, MaxReaps: 1, Logger: log.NewNopLogger()})
+
+	pid := spawn(t, "exit 0")
+	defer killChild(pid)
+
+	pidfd, err := pidfdOpen(pid)
+	if err != nil {
+		t.Skipf("no pidfds: %v", err)
+	}
+	r.mu.Lock()
+	r.pidfds[pid], r.claimed[pid] = pidfd, struct{}{}
+	r.mu.Unlock()
+
+	eventually(t, "the exit", func() bool {
+		wpid, err := peekChild(pid, wNOHANG)
+		return nil == err && pid == wpid
+	})
+
+	/*  Done reaping, the child is left be.  */
+	r.statsMu.Lock()
+	r.stats.Reaped = 1
+	r.statsMu.Unlock()
+
+	r.reapPidfd(context.Background(), pid)
+
+	r.mu.Lock()
+	_, held := r.pidfds[pid]
+	r.mu.Unlock()
+	if reaped := r.Stats().Reaped; !held || 1 != reaped {
+		t.Errorf("reaped %d, pidfd held %v past MaxReaps, expected 1 and the child left be", reaped, held)
+	}
+
+	/*  Not quite done, the child is reaped.  */
+	r.statsMu.Lock()
+	r.stats.Reaped = 0
+	r.statsMu.Unlock()
+
+	r.reapPidfd(context.Background(), pid)
+	eventually(t, "the reap", func() bool { return 1 == r.Stats().Reaped })
+
+} /*  End of function  TestPidfdMaxReaps.  */
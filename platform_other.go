@@ -0,0 +1,65 @@
+//go:build !(darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris)
+// +build !darwin,!dragonfly,!freebsd,!hurd,!illumos,!ios,!linux,!netbsd,!openbsd,!solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"os/exec"
+)
+
+/*
+ *  No zombies to reap here. Just enough of the platform bits so that the
+ *  package compiles, Run fails with ErrUnsupportedPlatform.
+ */
+const supported = false
+
+const (
+	wNOHANG     = 1
+	oNONBLOCK   = 0
+	waitOptions = wNOHANG
+)
+
+var sigCHLD os.Signal
+
+var sigUSR1, sigUSR2 os.Signal
+
+var initSignals []os.Signal
+
+var wait4 = func(pid int, wstatus *WaitStatus, options int, rusage *sysUsage) (int, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+func kill(pid int, sig Signal) error {
+	return ErrUnsupportedPlatform
+
+} /*  End of function  kill.  */
+
+func getpgid(pid int) (int, error) {
+	return 0, ErrUnsupportedPlatform
+
+} /*  End of function  getpgid.  */
+
+func isolate(cmd *exec.Cmd) {
+} /*  End of function  isolate.  */
+
+func forkExec(path string, args []string, dir string, env []string, deathsig Signal) (int, error) {
+	return 0, ErrUnsupportedPlatform
+
+} /*  End of function  forkExec.  */
+
+func rawStatus(wstatus WaitStatus) uint32 {
+	return uint32(wstatus.ExitStatus())
+
+} /*  End of function  rawStatus.  */
+
+func usageOf(rusage *sysUsage) Usage {
+	return Usage{}
+
+} /*  End of function  usageOf.  */
+
+func getpgrp() int {
+	return 0
+
+} /*  End of function  getpgrp.  */
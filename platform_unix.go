@@ -0,0 +1,105 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// Reaping is supported here.
+const supported = true
+
+const (
+	wNOHANG   = syscall.WNOHANG
+	oNONBLOCK = syscall.O_NONBLOCK
+
+	/*  The wait4(2) options that make sense for the reaper.  */
+	waitOptions = syscall.WNOHANG | syscall.WUNTRACED | wCONTINUED | linuxWaitOptions
+)
+
+// Signal telling us that a child changed state.
+var sigCHLD os.Signal = syscall.SIGCHLD
+
+// Signals asking for a state dump and a debug logging toggle (see
+// Config.DebugSignals).
+var sigUSR1, sigUSR2 os.Signal = syscall.SIGUSR1, syscall.SIGUSR2
+
+// Signals passed on to the primary child in init mode (see RunAsInit).
+var initSignals = []os.Signal{
+	syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT,
+	syscall.SIGUSR1, syscall.SIGUSR2,
+}
+
+// The wait4(2) used to reap the children, overridable so that the sweep
+// can be driven by canned results.
+var wait4 = syscall.Wait4
+
+// Send a signal to a process.
+func kill(pid int, sig syscall.Signal) error {
+	return syscall.Kill(pid, sig)
+
+} /*  End of function  kill.  */
+
+// Have a command start in a process group of its own.
+func isolate(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+
+} /*  End of function  isolate.  */
+
+// Fork off a child process, sharing our stdin, stdout and stderr. The
+// child gets deathsig (if any) when we die, see SetParentDeathSignal.
+func forkExec(path string, args []string, dir string, env []string, deathsig syscall.Signal) (int, error) {
+	sys := &syscall.SysProcAttr{}
+	if 0 != deathsig {
+		setPdeathsig(sys, deathsig, true)
+	}
+
+	return syscall.ForkExec(path, args, &syscall.ProcAttr{
+		Dir: dir,
+		Env: env,
+		Sys: sys,
+		Files: []uintptr{
+			uintptr(syscall.Stdin),
+			uintptr(syscall.Stdout),
+			uintptr(syscall.Stderr),
+		},
+	})
+
+} /*  End of function  forkExec.  */
+
+// The wait status as the kernel reported it.
+func rawStatus(wstatus syscall.WaitStatus) uint32 {
+	return uint32(wstatus)
+
+} /*  End of function  rawStatus.  */
+
+// The resource usage of a reaped child. ru_maxrss is in KiB, except on
+// darwin (and ios) where it is in bytes.
+func usageOf(rusage *syscall.Rusage) Usage {
+	if nil == rusage {
+		return Usage{}
+	}
+
+	maxrss := int64(rusage.Maxrss)
+	if "darwin" != runtime.GOOS && "ios" != runtime.GOOS {
+		maxrss *= 1024
+	}
+
+	return Usage{
+		UserTime:    time.Duration(rusage.Utime.Nano()),
+		SystemTime:  time.Duration(rusage.Stime.Nano()),
+		MaxRSS:      maxrss,
+		MinorFaults: int64(rusage.Minflt),
+		MajorFaults: int64(rusage.Majflt),
+	}
+
+} /*  End of function  usageOf.  */
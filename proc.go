@@ -0,0 +1,181 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Root of the proc filesystem, overridable so that the parsing can be
+// pointed at a fake proc tree.
+var procRoot = "/proc"
+
+// The bits of /proc/<pid>/stat we care about.
+type procStat struct {
+	pid     int
+	comm    string
+	state   byte
+	ppid    int
+	pgrp    int
+	session int
+
+	/*  Clock ticks since boot, zero if the record is cut short.  */
+	starttime uint64
+}
+
+// Clock ticks per second of the times in /proc (USER_HZ), which the
+// kernel pins at 100 for userspace on all the architectures.
+const procTicks = 100
+
+// Parse the contents of a /proc/<pid>/stat file. The comm field is in
+// parentheses and can itself contain spaces and parentheses, so the
+// remaining fields are split off after the last closing parenthesis.
+func parseProcStat(data []byte) (procStat, error) {
+	var stat procStat
+
+	start := bytes.IndexByte(data, '(')
+	end := bytes.LastIndexByte(data, ')')
+	if start < 0 || end < start {
+		return stat, errors.New("malformed proc stat: no comm field")
+	}
+
+	pid, err := strconv.Atoi(string(bytes.TrimSpace(data[:start])))
+	if err != nil {
+		return stat, fmt.Errorf("malformed proc stat pid: %v", err)
+	}
+
+	fields := bytes.Fields(data[end+1:])
+	if len(fields) < 4 || 1 != len(fields[0]) {
+		return stat, errors.New("malformed proc stat: too few fields")
+	}
+
+	ints := make([]int, 3)
+	for idx := range ints {
+		ints[idx], err = strconv.Atoi(string(fields[idx+1]))
+		if err != nil {
+			return stat, fmt.Errorf("malformed proc stat field %d: %v", idx+4, err)
+		}
+	}
+
+	stat.pid = pid
+	stat.comm = string(data[start+1 : end])
+	stat.state = fields[0][0]
+	stat.ppid, stat.pgrp, stat.session = ints[0], ints[1], ints[2]
+
+	/*  Field 22, which is the 20th one after the comm field.  */
+	if len(fields) >= 20 {
+		stat.starttime, _ = strconv.ParseUint(string(fields[19]), 10, 64)
+	}
+
+	return stat, nil
+
+} /*  End of function  parseProcStat.  */
+
+// Read and parse /proc/<pid>/stat for the given pid.
+func readProcStat(pid int) (procStat, error) {
+	path := filepath.Join(procRoot, strconv.Itoa(pid), "stat")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return procStat{}, err
+	}
+
+	return parseProcStat(data)
+
+} /*  End of function  readProcStat.  */
+
+// The system uptime, as per /proc/uptime.
+func procUptime() (time.Duration, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, "uptime"))
+	if err != nil {
+		return 0, err
+	}
+
+	fields := bytes.Fields(data)
+	if 0 == len(fields) {
+		return 0, errors.New("malformed proc uptime")
+	}
+
+	uptime, err := strconv.ParseFloat(string(fields[0]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed proc uptime: %v", err)
+	}
+
+	return time.Duration(uptime * float64(time.Second)), nil
+
+} /*  End of function  procUptime.  */
+
+// When the process with the given stat record was started, after boot.
+func procStarted(stat procStat) time.Duration {
+	return time.Duration(stat.starttime) * time.Second / procTicks
+
+} /*  End of function  procStarted.  */
+
+// How long ago the process with the given stat record was started, as
+// per the system uptime in /proc/uptime.
+func procAge(stat procStat) (time.Duration, error) {
+	if 0 == stat.starttime {
+		return 0, errors.New("no start time in proc stat")
+	}
+
+	uptime, err := procUptime()
+	if err != nil {
+		return 0, err
+	}
+
+	return uptime - procStarted(stat), nil
+
+} /*  End of function  procAge.  */
+
+// Read the command line of the given pid, split into its arguments. Empty
+// for kernel threads and zombies.
+func readProcCmdline(pid int) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimRight(data, "\x00")
+	if 0 == len(data) {
+		return nil, nil
+	}
+
+	args := make([]string, 0, bytes.Count(data, []byte{0})+1)
+	for _, arg := range bytes.Split(data, []byte{0}) {
+		args = append(args, string(arg))
+	}
+
+	return args, nil
+
+} /*  End of function  readProcCmdline.  */
+
+// Read and parse the stat records of all the processes in /proc. Any
+// process that goes away while we are at it is skipped.
+func listProcStats() ([]procStat, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []procStat
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		if stat, err := readProcStat(pid); err == nil {
+			stats = append(stats, stat)
+		}
+	}
+
+	return stats, nil
+
+} /*  End of function  listProcStats.  */
@@ -0,0 +1,121 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// A fake proc tree to point procRoot at, restored once the test is done.
+type fakeProc struct {
+	t    *testing.T
+	root string
+}
+
+// Point procRoot at a fresh fake proc tree.
+func newFakeProc(t *testing.T) *fakeProc {
+	t.Helper()
+
+	saved := procRoot
+	procRoot = t.TempDir()
+	t.Cleanup(func() { procRoot = saved })
+
+	return &fakeProc{t: t, root: procRoot}
+
+} /*  End of function  newFakeProc.  */
+
+// Write a file of the fake proc tree, e.g. "uptime" or "42/cmdline".
+func (p *fakeProc) write(name, data string) {
+	p.t.Helper()
+
+	path := filepath.Join(p.root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		p.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		p.t.Fatal(err)
+	}
+
+} /*  End of method  fakeProc.write.  */
+
+// Add a process to the fake proc tree, started the given number of clock
+// ticks after boot.
+func (p *fakeProc) process(pid int, comm string, state byte, ppid int, starttime uint64) {
+	p.t.Helper()
+
+	/*  pid (comm) state ppid pgrp session, then up to starttime.  */
+	stat := fmt.Sprintf("%d (%s) %c %d %d %d 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 %d 0 0\n",
+		pid, comm, state, ppid, pid, pid, starttime)
+	p.write(filepath.Join(strconv.Itoa(pid), "stat"), stat)
+
+} /*  End of method  fakeProc.process.  */
+
+func TestParseProcStat(t *testing.T) {
+	stat, err := parseProcStat([]byte("42 (a (weird) comm) Z 7 42 1 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 12345 0 0\n"))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	expected := procStat{pid: 42, comm: "a (weird) comm", state: 'Z', ppid: 7, pgrp: 42, session: 1, starttime: 12345}
+	if expected != stat {
+		t.Errorf("parsed %+v, expected %+v", stat, expected)
+	}
+
+	/*  Cut short before the start time, which is then zero.  */
+	stat, err = parseProcStat([]byte("42 (sh) S 7 42 1"))
+	if err != nil || 0 != stat.starttime || 7 != stat.ppid {
+		t.Errorf("parsed %+v, %v - expected ppid 7 and no start time", stat, err)
+	}
+
+	for _, data := range []string{"", "42 sh S 7 42 1", "x (sh) S 7 42 1", "42 (sh) S 7", "42 (sh) S x 42 1", "42 (sh) SS 7 42 1"} {
+		if _, err := parseProcStat([]byte(data)); nil == err {
+			t.Errorf("parsed %q, expected it to be malformed", data)
+		}
+	}
+
+} /*  End of function  TestParseProcStat.  */
+
+func TestListProcStats(t *testing.T) {
+	proc := newFakeProc(t)
+	proc.process(1, "init", 'S', 0, 1)
+	proc.process(42, "sh", 'Z', 1, 100)
+	proc.write("self/stat", "not a process")
+	proc.write("uptime", "12.5 40.0\n")
+	proc.write("7/stat", "garbage")
+
+	stats, err := listProcStats()
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+
+	if 2 != len(stats) || 1 != stats[0].pid || 42 != stats[1].pid {
+		t.Fatalf("listed %+v, expected pids 1 and 42", stats)
+	}
+
+	if _, err := readProcStat(43); nil == err {
+		t.Errorf("read the stat of a process that isn't there")
+	}
+
+} /*  End of function  TestListProcStats.  */
+
+func TestOrphaned(t *testing.T) {
+	r := New(Config{Pid: -1, DetectOrphans: true})
+	r.waiters[101] = make(chan ReapEvent, 1)
+	r.claimed[102] = struct{}{}
+
+	/*  Launched by us, registered with us - or someone else's.  */
+	for pid, expected := range map[int]bool{100: true, 101: false, 102: false} {
+		if orphan := r.orphaned(pid); expected != orphan {
+			t.Errorf("pid %d tagged orphan %v, expected %v", pid, orphan, expected)
+		}
+	}
+
+	r = New(Config{Pid: -1})
+	if r.orphaned(100) {
+		t.Errorf("orphan tagged without DetectOrphans")
+	}
+
+} /*  End of function  TestOrphaned.  */
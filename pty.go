@@ -0,0 +1,146 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+/*  How long to wait for the last of the output once the child is gone.  */
+const ptyDrainTimeout = 1 * time.Second
+
+// ptyProxy Relays the primary child's stdio through a pty of its own (see
+// Config.InitPTY): what comes in on the command's original stdin goes to
+// the pty, what the child writes to the pty to the original stdout.
+type ptyProxy struct {
+	master *os.File
+	slave  *os.File
+	input  io.Reader
+	output io.Writer
+
+	/*  Our terminal, if the input is one, and how to put it back.  */
+	tty     *os.File
+	restore []func()
+
+	copied chan struct{}
+}
+
+// Set the command up to run on a new pty, in a session of its own with
+// the pty as its controlling terminal.
+func newPTYProxy(cmd *exec.Cmd) (*ptyProxy, error) {
+	master, slave, err := openPTY()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ptyProxy{
+		master: master,
+		slave:  slave,
+		input:  cmd.Stdin,
+		output: cmd.Stdout,
+		copied: make(chan struct{}),
+	}
+	if nil == p.output {
+		p.output = io.Discard
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	newSession(cmd)
+
+	return p, nil
+
+} /*  End of function  newPTYProxy.  */
+
+// Relay input to the pty. Input from a terminal is read through a
+// non-blocking duplicate of it, so that stop can cut a pending read short.
+func (p *ptyProxy) relayInput(logger Logger) {
+	if nil == p.input {
+		return
+	}
+
+	input := p.input
+	if file, ok := input.(*os.File); ok {
+		/*  Just the once, File.Fd would undo the non-blocking mode.  */
+		if fd := file.Fd(); isTerminal(fd) {
+			input = p.fromTerminal(logger, file, fd)
+		}
+	}
+
+	go io.Copy(p.master, input)
+
+} /*  End of method  ptyProxy.relayInput.  */
+
+// Set up relaying from our terminal: raw mode, resizes passed on and a
+// non-blocking duplicate of it to read from. Returns what to read from.
+func (p *ptyProxy) fromTerminal(logger Logger, file *os.File, fd uintptr) io.Reader {
+	var input io.Reader = file
+	if tty, restore, err := pollable(file); nil == err {
+		p.tty, input = tty, tty
+		p.restore = append(p.restore, restore)
+	}
+
+	if restore, err := makeRaw(fd); err != nil {
+		level.Warn(logger).Log("msg", "failed to put the terminal into raw mode", "err", err)
+	} else {
+		p.restore = append(p.restore, restore)
+	}
+
+	/*  Keep the pty the size of our terminal.  */
+	copyWinsize(fd, p.master)
+	if sigWINCH != nil {
+		resized := make(chan os.Signal, 1)
+		signal.Notify(resized, sigWINCH)
+		p.restore = append(p.restore, func() { signal.Stop(resized); close(resized) })
+
+		go func() {
+			for range resized {
+				copyWinsize(fd, p.master)
+			}
+		}()
+	}
+
+	return input
+
+} /*  End of method  ptyProxy.fromTerminal.  */
+
+// Start relaying, once the child is started.
+func (p *ptyProxy) start(logger Logger) {
+	/*  The child has its own, the pty hangs up once it is gone.  */
+	p.slave.Close()
+
+	go func() {
+		defer close(p.copied)
+		io.Copy(p.output, p.master)
+	}()
+
+	p.relayInput(logger)
+
+} /*  End of method  ptyProxy.start.  */
+
+// Stop relaying once the child is gone: wait (a little, whatever it left
+// running may hold on to the pty) for the last of its output, then put
+// our terminal back the way it was.
+func (p *ptyProxy) stop() {
+	select {
+	case <-p.copied:
+	case <-time.After(ptyDrainTimeout):
+	}
+
+	p.master.Close()
+	if p.tty != nil {
+		p.tty.SetReadDeadline(time.Now())
+		p.tty.Close()
+	}
+
+	for idx := len(p.restore) - 1; idx >= 0; idx-- {
+		p.restore[idx]()
+	}
+
+} /*  End of method  ptyProxy.stop.  */
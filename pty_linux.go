@@ -0,0 +1,124 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+/*  We get to hand out ptys of our own.  */
+const ptySupported = true
+
+// An ioctl(2) on an open file, leaving it as it is (non-blocking and on
+// the runtime poller), unlike File.Fd.
+func ioctlFile(file *os.File, req uintptr, arg unsafe.Pointer) error {
+	conn, err := file.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var errno syscall.Errno
+	if err := conn.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg))
+	}); err != nil {
+		return err
+	}
+	if 0 != errno {
+		return errno
+	}
+
+	return nil
+
+} /*  End of function  ioctlFile.  */
+
+// Open a new pty, returns its master and slave side.
+func openPTY() (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		unlock int32
+		index  uint32
+	)
+	if err := ioctlFile(master, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	if err := ioctlFile(master, syscall.TIOCGPTN, unsafe.Pointer(&index)); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(int(index)), os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+
+	return master, slave, nil
+
+} /*  End of function  openPTY.  */
+
+// A non-blocking duplicate of file, so that reads from it can be cut
+// short (see File.SetReadDeadline). Non-blocking is a property of what
+// the two share, the returned func makes file blocking again.
+func pollable(file *os.File) (*os.File, func(), error) {
+	orig := int(file.Fd())
+	fd, err := syscall.Dup(orig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		syscall.Close(fd)
+		return nil, nil, err
+	}
+
+	return os.NewFile(uintptr(fd), file.Name()), func() {
+		syscall.SetNonblock(orig, false)
+	}, nil
+
+} /*  End of function  pollable.  */
+
+// Put the terminal at fd into raw mode (as cfmakeraw(3) does), so that
+// every key press makes it through to the pty as is. Returns a func that
+// restores the terminal's previous mode.
+func makeRaw(fd uintptr) (func(), error) {
+	var saved syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&saved))); 0 != errno {
+		return nil, errno
+	}
+
+	raw := saved
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN], raw.Cc[syscall.VTIME] = 1, 0
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&raw))); 0 != errno {
+		return nil, errno
+	}
+
+	return func() {
+		syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&saved)))
+	}, nil
+
+} /*  End of function  makeRaw.  */
+
+// Give the pty the size of the terminal at fd. The kernel lets the pty's
+// foreground process group know (SIGWINCH) if that is a change.
+func copyWinsize(fd uintptr, master *os.File) error {
+	var size [4]uint16
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); 0 != errno {
+		return errno
+	}
+
+	return ioctlFile(master, syscall.TIOCSWINSZ, unsafe.Pointer(&size))
+
+} /*  End of function  copyWinsize.  */
@@ -0,0 +1,30 @@
+//go:build !linux
+// +build !linux
+
+package reaper
+
+//  Prefer #include style directives.
+import "os"
+
+/*  Ptys of our own are a linux thing, for now.  */
+const ptySupported = false
+
+func openPTY() (*os.File, *os.File, error) {
+	return nil, nil, ErrUnsupportedPlatform
+
+} /*  End of function  openPTY.  */
+
+func pollable(file *os.File) (*os.File, func(), error) {
+	return nil, nil, ErrUnsupportedPlatform
+
+} /*  End of function  pollable.  */
+
+func makeRaw(fd uintptr) (func(), error) {
+	return nil, ErrUnsupportedPlatform
+
+} /*  End of function  makeRaw.  */
+
+func copyWinsize(fd uintptr, master *os.File) error {
+	return ErrUnsupportedPlatform
+
+} /*  End of function  copyWinsize.  */
@@ -0,0 +1,65 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// logLimiter Caps the reap log lines at so many per second (see
+// Config.MaxReapLogsPerSecond), counting the ones it suppresses.
+type logLimiter struct {
+	mu         sync.Mutex
+	limit      int
+	window     time.Time
+	logged     int
+	suppressed int
+}
+
+// Check if a line may be logged now. Also returns the number of lines
+// suppressed in the window before, on the first line of a new window -
+// so that it can be summed up.
+func (l *logLimiter) allow(now time.Time) (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	suppressed := 0
+	if now.Sub(l.window) >= time.Second {
+		suppressed = l.suppressed
+		l.window, l.logged, l.suppressed = now, 0, 0
+	}
+
+	if l.logged >= l.limit {
+		l.suppressed++
+		return false, 0
+	}
+
+	l.logged++
+	return true, suppressed
+
+} /*  End of method  logLimiter.allow.  */
+
+// Check if a reap log line may be logged, logging a summary of the lines
+// suppressed before if need be. Always true without a limit set.
+func (r *Reaper) logAllowed() bool {
+	if nil == r.logLimit {
+		return true
+	}
+
+	ok, suppressed := r.logLimit.allow(time.Now())
+	if !ok {
+		r.countStat(func(stats *Stats) { stats.SuppressedLogs++ })
+		return false
+	}
+
+	if suppressed > 0 {
+		level.Info(r.config.Logger).Log("msg", "suppressed reap log lines", "count", suppressed, "limit", r.config.MaxReapLogsPerSecond)
+	}
+
+	return true
+
+} /*  End of method  Reaper.logAllowed.  */
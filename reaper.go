@@ -5,10 +5,17 @@ package reaper
 //  Prefer #include style directives.
 import (
 	"context"
-	"errors"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"os/signal"
+	"runtime"
+	"runtime/trace"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
@@ -18,22 +25,521 @@ type Logger interface {
 	Log(keyvals ...interface{}) error
 }
 
+// Config Reaper configuration. When adding fields that can't be rendered
+// as JSON (funcs, interfaces, channels), shadow them in MarshalJSON.
 type Config struct {
 	Pid              int
 	Options          int
 	DisablePid1Check bool
 	Debug            bool
 	Logger           Logger
+
+	// Slog Log to this *slog.Logger (see SlogLogger) unless a Logger is
+	// set, no need to pull in go-kit/log just for the Logger interface.
+	Slog *slog.Logger
+
+	// DetectOrphans Tag reap events for children we neither launched nor
+	// had registered with us as orphans (see ReapEvent.Orphan).
+	DetectOrphans bool
+
+	// TrackLifetimes Work out how long the children we did not launch
+	// ourselves lived as well, from their start time as per
+	// /proc/<pid>/stat (see ReapEvent.Lifetime). Costs an extra waitid(2)
+	// peek and a /proc/<pid>/stat read per reaped child (linux only).
+	TrackLifetimes bool
+
+	// AssumeAutoReap Run as a pure observer: SIGCHLDs are still logged
+	// but the reaper never waits on any children. Use this when SIGCHLD
+	// is ignored or has SA_NOCLDWAIT set (kernel auto-reaps children),
+	// waiting would otherwise race with the kernel or block until all
+	// the children are gone. Note that no SIGCHLDs are delivered at all
+	// if SIGCHLD is ignored.
+	AssumeAutoReap bool
+
+	// FallbackFormat Format of the records written to stderr for a
+	// reaped child when the logger fails to log it. Defaults to a
+	// "REAPER-FALLBACK key=value ..." text line.
+	FallbackFormat FallbackFormat
+
+	// ReapCallback Invoked for every reaped child, from the reaper's
+	// goroutine - so keep it short - unless CallbackWorkers is set.
+	ReapCallback func(ctx context.Context, event ReapEvent)
+
+	// OnReap Simpler take on ReapCallback, for hooks that don't need a
+	// context: invoked for every reaped child with its pid, wait status
+	// and reap time (see ReapEvent). Runs just like ReapCallback does
+	// (and before it, if both are set).
+	OnReap func(event ReapEvent)
+
+	// StatusChannel Channel to publish all the reap events on, just like
+	// to a subscriber (see Subscribe): sends never block, events that
+	// don't fit are dropped and counted in Stats.DroppedEvents. Make it
+	// buffered. The reaper never closes it.
+	StatusChannel chan<- ReapEvent
+
+	// CallbackWorkers Number of goroutines running ReapCallback (and
+	// OnReap) asynchronously. Events are queued up for them in a queue
+	// holding up to CallbackQueueSize (default 64) events, when the
+	// queue is full the CallbackOverflow policy kicks in. Zero means
+	// callbacks run synchronously. On shutdown, the queue is drained
+	// before Run returns.
+	CallbackWorkers      int
+	CallbackQueueSize    int
+	CallbackOverflow     CallbackOverflow
+	CallbackBlockTimeout time.Duration
+
+	// CallbackContext Context passed to the callbacks. Takes precedence
+	// over the context passed to Run, which is used if this is nil.
+	// Set it to have callbacks carry long-lived values (tracing etc)
+	// that outlive the reaper's lifecycle context, so that callbacks
+	// don't see a cancelled context while the reaper shuts down.
+	CallbackContext context.Context
+
+	// HealthAddr Address to run a tiny HTTP server on, serving /healthz
+	// (liveness of the reap loop) and /stats (the reaper's stats as
+	// JSON). The server is shut down when Run returns.
+	HealthAddr string
+
+	// HealthStaleAfter Have /healthz answer 503 once the reap loop has
+	// shown no signs of life for this long while there are children it
+	// may wait on (see Children), e.g. when it is stuck in a callback.
+	// The loop checks in at half this interval. Zero never goes stale.
+	HealthStaleAfter time.Duration
+
+	// ControlSocket Path of a unix socket to serve a control interface
+	// on, for operators to poke at a running reaper (e.g. with socat or
+	// nc -U). It takes one command per line and answers each with a line
+	// of JSON: stats, children (see Children), reap-now (sweep right
+	// away) and set-log-level debug|info|warn|error (default logger
+	// only). The socket is only accessible to our own user and is
+	// removed when Run returns.
+	ControlSocket string
+
+	// DebugSignals Handle SIGUSR1 and SIGUSR2 for operators without a
+	// control socket: SIGUSR1 logs a dump of the stats, the children
+	// (see Children) and the stacks of all goroutines at info, SIGUSR2
+	// toggles debug logging on and off (default logger only). Note that
+	// in init mode (see RunAsInit) both are still passed on to the
+	// primary child as well.
+	DebugSignals bool
+
+	// NotifySystemd Let systemd know how we are doing (see sd_notify(3))
+	// when running as a service with Type=notify: READY=1 once the reap
+	// loop is up, STOPPING=1 on the way out and - if the unit has
+	// WatchdogSec= set - WATCHDOG=1 heartbeats at half the watchdog
+	// timeout. The heartbeats are sent from the reap loop itself, so a
+	// wedged reaper (e.g. stuck in a callback) gets restarted by systemd.
+	// A no-op when not running under systemd.
+	NotifySystemd bool
+
+	// ExpvarName Publish the reaper's stats via expvar (/debug/vars)
+	// under this name once Run starts: children reaped, the time of the
+	// last reap, wait errors and the drop counts. Empty publishes
+	// nothing. As expvars can't be unpublished, the stats stay around
+	// (frozen) once the reaper is done - and a name already taken is
+	// left alone.
+	ExpvarName string
+
+	// EnableRuntimeTrace Annotate the reaper for the execution tracer
+	// (runtime/trace, `go tool trace`): a "grim-reaper" task for the
+	// lifetime of Run with a "sweep" region per sweep, logging the
+	// number of children reaped in it. No overhead when disabled.
+	EnableRuntimeTrace bool
+
+	// CancelIsClean Have Run return nil when its context is cancelled
+	// (a normal shutdown) rather than context.Canceled. Cancelling with
+	// a cause (context.WithCancelCause) returns that cause instead and
+	// a deadline still returns context.DeadlineExceeded.
+	CancelIsClean bool
+
+	// OnContinued Invoked (from the reaper's goroutine) for a child that
+	// was resumed by SIGCONT. Needs WCONTINUED in Options. These are not
+	// reaps: they don't count as such or reach ReapCallback but they are
+	// published to subscribers as EventContinued events.
+	OnContinued func(pid int)
+
+	// DetectAdoptions Report orphans as they get re-parented to us, not
+	// just once they die: whenever the reaper wakes up (and every second
+	// or so while it waits on living children), it looks for new
+	// children of ours in /proc and publishes them to subscribers as
+	// EventAdopted events, with their comm and command line. Children
+	// we didn't launch ourselves nor had registered can't be told apart
+	// from orphans, so those started via os/exec show up too.
+	DetectAdoptions bool
+
+	// TrackChildren Keep track of our process tree (see Children) while
+	// the reaper runs: whenever it wakes up (and every second or so while
+	// it waits on living children), it looks up our children and their
+	// descendants in /proc.
+	TrackChildren bool
+
+	// OnAdopted Invoked (from the reaper's goroutine) for every orphan
+	// detected as per DetectAdoptions.
+	OnAdopted func(event ReapEvent)
+
+	// OnStopped Invoked (from the reaper's goroutine) for a child that
+	// was stopped by a signal (SIGSTOP, SIGTSTP and friends). Needs
+	// WUNTRACED in Options. Just like OnContinued, these are not reaps
+	// and are published to subscribers as EventStopped events.
+	OnStopped func(pid int)
+
+	// MaxReaps Stop once this many children have been reaped - Run then
+	// returns nil with ShutdownReason() ReasonMaxReaps. The reaper never
+	// reaps more than that. Zero means no limit.
+	MaxReaps int
+
+	// MaxWakeupsPerSecond Cap on how often SIGCHLDs wake up the reap
+	// loop. Any signals beyond that are coalesced into one wakeup, as
+	// a single sweep reaps all the dead children anyway. Protects the
+	// signal handling under a signal storm. Zero means no limit.
+	MaxWakeupsPerSecond int
+
+	// NotificationQueueSize Number of SIGCHLD wakeups (default 1) that
+	// can be pending for the reap loop. Signals beyond that are dropped
+	// and counted in Stats.DroppedSignals - harmless, as one sweep reaps
+	// all the dead children, but a deeper queue smooths out bursts on
+	// workloads with heavy churn.
+	NotificationQueueSize int
+
+	// OnDrop Invoked (from the signal handling goroutine) for every
+	// signal dropped rather than waking up the reap loop. Keep it short,
+	// signals are not handled while it runs.
+	OnDrop func(sig os.Signal)
+
+	// MaxReapLogsPerSecond Cap on the log lines logged per reap (and
+	// per wakeup), so that a fork bomb or a crash loop doesn't flood
+	// the logs. Lines beyond that are dropped (and counted in Stats),
+	// the next line logged after is preceded by one summing up how many
+	// were. Callbacks, subscribers and the event sinks still get all the
+	// events. Zero means no limit.
+	MaxReapLogsPerSecond int
+
+	// AllowHostReaping Reap even if we are pid 1 on the host rather
+	// than in a container (e.g. a misconfigured systemd unit), which
+	// the reaper otherwise refuses to do. Container detection is best
+	// effort: marker files (/.dockerenv etc), the container env var,
+	// the pid namespace and the cgroups.
+	AllowHostReaping bool
+
+	// StatsInterval and OnStats Every StatsInterval, OnStats is handed
+	// a snapshot of the stats, including the number of children reaped
+	// in the interval and the reap rate. It runs in a goroutine of its
+	// own, not holding up the reaper.
+	StatsInterval time.Duration
+	OnStats       func(stats Stats)
+
+	// ExpectedExitCodes Non-zero exit codes that are expected of any
+	// child. Reaps of children exiting with other non-zero codes (or
+	// killed by a signal not sent by the reaper) are then marked as
+	// Unexpected and logged at warn level. Without it, only children
+	// the reaper launched or that have exit codes registered via
+	// ExpectExitCodes are checked.
+	ExpectedExitCodes []int
+
+	// FailureHistorySize Number of failed, signaled and core dumped reaps
+	// to keep around for RecentFailures. Zero keeps none.
+	FailureHistorySize int
+
+	// EventHistorySize Number of reaps (of any outcome) to keep around
+	// for RecentEvents, e.g. 256 - to look into what died just before a
+	// container crashed without digging through the logs. Zero keeps
+	// none.
+	EventHistorySize int
+
+	// CheckpointFile and CheckpointInterval Periodically persist the
+	// stats to CheckpointFile (every minute unless CheckpointInterval is
+	// set) and once more on the way out, so that a restarted reaper can
+	// pick them up again with LoadCheckpoint.
+	CheckpointFile     string
+	CheckpointInterval time.Duration
+
+	// YieldEvery Yield the processor (runtime.Gosched) after every
+	// YieldEvery reaps within a sweep, so that draining a large backlog
+	// of zombies doesn't hold up other goroutines when the processors
+	// are busy. This trades a bit of reap throughput for latency of the
+	// rest of the program. Zero never yields.
+	YieldEvery int
+
+	// TargetGroup Only reap the children in this process group, leaving
+	// any other children for someone else to wait on. Overrides Pid and
+	// adds WNOHANG to Options, as SIGCHLD is sent for every child: the
+	// SIGCHLDs of children outside the group cause harmless sweeps that
+	// reap nothing.
+	TargetGroup int
+
+	// Cgroup Only reap the children in this cgroup (or below it), as per
+	// the unified (cgroup v2) hierarchy in /proc/<pid>/cgroup - e.g. so
+	// that a sidecar reaper in a pid namespace shared between containers
+	// doesn't take another container's children. The path is as seen in
+	// our own cgroup namespace. Every zombie is looked up in /proc before
+	// it is reaped, so this needs /proc (nothing is reaped without it)
+	// and the unified hierarchy: on the v1 ones zombies all show up in
+	// the root cgroup.
+	Cgroup string
+
+	// CgroupReportOnly Reap all the children still, but only report the
+	// ones in Cgroup (log, callbacks, events, stats). The others are just
+	// counted in Stats.Unreported.
+	CgroupReportOnly bool
+
+	// VerifyCleanShutdown Once the reaper stops, scan /proc for any of
+	// our children left behind as zombies and log them (pid and comm).
+	// Run then returns an error wrapping ErrZombiesRemain rather than
+	// reporting a clean shutdown. Skipped where there is no /proc.
+	VerifyCleanShutdown bool
+
+	// SweepOnSignals Further signals (e.g. SIGUSR1) that make the reaper
+	// sweep for dead children right away, just like a SIGCHLD does - a
+	// manual "reap now" for children known to have died out-of-band.
+	// These go through the same wakeup coalescing as SIGCHLDs. Other
+	// signal.Notify users (e.g. shutdown handling) still get them too.
+	SweepOnSignals []os.Signal
+
+	// SweepInterval Also sweep for dead children every SweepInterval
+	// (e.g. 30s), SIGCHLD or not - a safety net for the pathological
+	// cases where SIGCHLDs get lost, say a signal mask inherited from a
+	// misbehaving parent. Children only caught by these sweeps are
+	// counted in Stats.SweepReaped. Zero doesn't sweep periodically.
+	SweepInterval time.Duration
+
+	// PeekMode Peek at the next child to reap with waitid(2) WNOWAIT and
+	// wait on just that child, rather than on any child - so the reaper
+	// never takes a status that a registered child's owner is about to
+	// wait for (see Register), and only falls back to looking up the
+	// zombies in /proc when the next child is a registered one. Costs
+	// an extra syscall per reaped child (linux only).
+	PeekMode bool
+
+	// EnableSubreaper Make the reaper a child subreaper (via prctl
+	// PR_SET_CHILD_SUBREAPER on linux, procctl PROC_REAP_ACQUIRE on
+	// FreeBSD) so that orphaned descendants get re-parented to us rather
+	// than to pid 1 - within a FreeBSD jail as much as a linux container.
+	// A subreaper doesn't need to be pid 1, so the pid 1 (and container)
+	// checks are skipped once that worked. Run fails if it doesn't.
+	EnableSubreaper bool
+
+	// UsePidfd Keep hold of a pidfd (pidfd_open(2), linux 5.4+) for every
+	// child launched via Supervise and wait on exactly that process with
+	// waitid(2) P_PIDFD once the pidfd polls readable, rather than in the
+	// SIGCHLD-driven sweeps - which leave such children alone, as if they
+	// were registered (see Register). Signals to them go through the
+	// pidfd too, so neither can hit an unrelated process that reused the
+	// pid. Children launched where pidfds aren't available are waited on
+	// as usual.
+	UsePidfd bool
+
+	// ForwardSignals Signals to pass on to the primary child (see
+	// RunAsInit and ForwardSignals) - as pid 1, it gets no default
+	// signal dispositions, so it is up to us to pass termination signals
+	// on. Defaults to SIGTERM, SIGINT, SIGHUP, SIGQUIT, SIGUSR1 and
+	// SIGUSR2, set it to an empty slice to pass none on.
+	ForwardSignals []os.Signal
+
+	// ParentDeathSignal Signal (e.g. SIGTERM) for the children spawned
+	// through the reaper - see Supervise, Command, RunAsInit and the
+	// supervise package - to be sent should we die, rather than be
+	// silently orphaned to the host (see SetParentDeathSignal). Zero
+	// sends none. Linux and FreeBSD only.
+	ParentDeathSignal Signal
+
+	// KillProcessGroup Pass forwarded signals on to the primary child's
+	// whole process group (like tini -g), so that its own children get
+	// them too. RunAsInit starts the child in a process group of its own
+	// for that, a primary child in our process group just gets them
+	// itself. If its stdin is a terminal, the child's group is no longer
+	// told about the terminal being resized, so SIGWINCH is passed on to
+	// it as well.
+	KillProcessGroup bool
+
+	// InitSession Start the primary child (see RunAsInit) in a session of
+	// its own, taking its stdin - if that is a terminal - as its
+	// controlling terminal. Taking over a terminal that already is the
+	// controlling terminal of our session needs CAP_SYS_ADMIN, without
+	// that the child fails to start; use InitForeground instead.
+	InitSession bool
+
+	// InitForeground If the primary child's stdin is our controlling
+	// terminal (e.g. `docker run -it`), start it in a process group of
+	// its own and make that the terminal's foreground process group, as
+	// tini does: ^C and ^Z then reach the child (and whatever it starts
+	// in its group) straight from the terminal, and it gets to read from
+	// the terminal without being stopped (SIGTTIN). We are left in the
+	// background and ignore SIGTTIN and SIGTTOU until RunAsInit returns -
+	// which the children the reaper starts meanwhile inherit.
+	InitForeground bool
+
+	// InitPTY Run the primary child on a pty of its own, in a session of
+	// its own with the pty as its controlling terminal, relaying its
+	// stdin and stdout (stderr is merged into stdout, as for `docker run
+	// -t`) through the pty. If stdin is a terminal, it is put into raw
+	// mode while the child runs and any resizes are passed on to the pty,
+	// so interactive programs behave just as if they ran on it directly.
+	// Linux only.
+	InitPTY bool
+
+	// GracePeriod Shut the remaining children down once the context is
+	// done, as a well-behaved init does when it goes away: SIGTERM all
+	// our direct children (as listed in /proc, or just the ones we
+	// launched without it), keep reaping for up to GracePeriod and then
+	// SIGKILL (and reap) the stragglers before Run returns. Supervised
+	// children can have a grace period of their own, see
+	// ChildSpec.GracePeriod. In init mode (see RunAsInit), the primary
+	// child is likewise sent a SIGTERM first and only killed once the
+	// grace period is up. Zero leaves the children be.
+	GracePeriod time.Duration
+
+	// DisableFinalSweep Return straight away once the context is done.
+	// By default, the reaper does one last (WNOHANG) sweep on the way
+	// out, so that children which exited while it was shutting down are
+	// still reaped and reported rather than left behind as zombies.
+	DisableFinalSweep bool
+
+	// EventSocket and EventFIFO Stream reap events, one JSON object per
+	// line, to the unix socket listening at EventSocket and/or to the
+	// FIFO at EventFIFO. Events are queued up (up to EventQueueSize,
+	// default 64) and written out by a goroutine of their own, events
+	// that don't fit are dropped and counted in Stats. A reader that
+	// stalls or goes away is reconnected to with backoff.
+	EventSocket    string
+	EventFIFO      string
+	EventQueueSize int
+
+	// EventWriter Write the reap events as JSON lines to this writer
+	// too, e.g. os.Stdout for a log pipeline (Fluent Bit and the like)
+	// to pick up. Same format and queueing as for EventSocket: pid,
+	// time, outcome, exit code or signal, core dump and the resources
+	// used. Each event goes out in a single Write. After a write error
+	// events are dropped for a while (with backoff), the writer is never
+	// closed.
+	EventWriter io.Writer
+
+	// AuditFile Append every reap event to this file as well, for a post
+	// mortem of what kept dying - put it on a volume to have it survive
+	// the container. AuditFormat is "json" (the default, the same as for
+	// EventSocket) or "logfmt". Once the next event would take the file
+	// past AuditMaxSize bytes (default 10 MiB), it is rotated to
+	// AuditFile.1, the previous AuditFile.1 to AuditFile.2 and so on,
+	// keeping AuditMaxBackups (default 3) rotated files. Same queueing
+	// as for EventSocket.
+	AuditFile       string
+	AuditFormat     string
+	AuditMaxSize    int64
+	AuditMaxBackups int
+
+	// WebhookURL POST every reap, as a JSON object (the same as for
+	// EventSocket), to this URL - e.g. to catch crashing children
+	// centrally, without scraping container logs. Reaps are queued up
+	// (up to EventQueueSize) and delivered by a goroutine of their own,
+	// a failed delivery (a network error, a 429 or 5xx answer) is
+	// retried with backoff, up to 5 attempts. Reaps that don't fit in
+	// the queue or fail for good are dropped and counted in Stats.
+	WebhookURL string
+}
+
+// Reaper A grim reaper instance. Besides reaping orphaned children, a
+// Reaper keeps track of the children it launched itself (see Supervise)
+// so that their exit status is handed back rather than just logged.
+type Reaper struct {
+	config Config
+
+	mu          sync.Mutex
+	waiters     map[int]chan ReapEvent
+	watchers    map[int][]chan ReapEvent
+	claimed     map[int]struct{}
+	signaled    map[int]uint64
+	expected    map[int][]int
+	launched    map[int]time.Time
+	graces      map[int]time.Duration
+	pidfds      map[int]*os.File
+	subscribers []chan ReapEvent
+	exits       chan int
+	sweeps      chan chan int
+	watchdog    <-chan time.Time
+	heartbeat   <-chan time.Time
+	seq         uint64
+	failures    *eventRing
+	history     *eventRing
+	tree        []Child
+	logLimit    *logLimiter
+	logLevel    *levelFilter
+	collect     bool
+	collected   []ReapEvent
+
+	/*  Our children as of the last adoption scan, reap loop only.  */
+	known map[int]struct{}
+
+	callbacks *callbackQueue
+	sinks     []*eventSink
+	webhook   *webhook
+
+	statsMu      sync.Mutex
+	stats        Stats
+	running      bool
+	handling     bool
+	lastActivity time.Time
+	reason       ShutdownReason
+	err          error
+
+	started   bool
+	ready     chan struct{}
+	readyOnce sync.Once
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+	doneOnce  sync.Once
 }
 
 // Handle death of child (SIGCHLD) messages. Pushes the signal onto the
-// notifications channel if there is a waiter.
-func sigChildHandler(ctx context.Context, notifications chan os.Signal) {
-	var sigs = make(chan os.Signal, 3)
-	signal.Notify(sigs, syscall.SIGCHLD)
+// notifications channel if there is a waiter. With MaxWakeupsPerSecond
+// set, signals arriving too quickly after the last wakeup are coalesced
+// into a single wakeup once the rate allows for it.
+func (r *Reaper) sigChildHandler(ctx context.Context, sigs chan os.Signal, notifications chan os.Signal) {
+	var interval time.Duration
+	if r.config.MaxWakeupsPerSecond > 0 {
+		interval = time.Second / time.Duration(r.config.MaxWakeupsPerSecond)
+	}
+
+	var (
+		last    time.Time
+		pending os.Signal
+		wakeup  <-chan time.Time
+	)
 
 	for {
-		var sig = <-sigs
+		var sig os.Signal
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case sig = <-sigs:
+			wait := interval - time.Since(last)
+			if interval > 0 && wait > 0 {
+				/*
+				 *  Too soon - coalesce it into a pending
+				 *  wakeup, arming a timer for it if needed.
+				 */
+				if pending != nil {
+					r.dropped(pending)
+				} else {
+					wakeup = time.After(wait)
+				}
+				pending = sig
+				continue
+			}
+
+			if pending != nil {
+				/*  This one covers for the pending one.  */
+				r.dropped(pending)
+				pending, wakeup = nil, nil
+			}
+
+		case <-wakeup:
+			sig, pending, wakeup = pending, nil, nil
+		}
+
+		last = time.Now()
+
 		select {
 		case <-ctx.Done():
 			return
@@ -45,48 +551,624 @@ func sigChildHandler(ctx context.Context, notifications chan os.Signal) {
 			 *  queue. The reaper just waits for any child
 			 *  process (pid=-1), so we ain't loosing it!! ;^)
 			 */
+			r.dropped(sig)
 		}
 	}
 
-} /*  End of function  sigChildHandler.  */
+} /*  End of method  Reaper.sigChildHandler.  */
 
-// Be a good parent - clean up behind the children.
-func reapChildren(ctx context.Context, config Config) error {
-	logger := config.Logger
-	var notifications = make(chan os.Signal, 1)
+// Count a signal that didn't wake up the reap loop, and pass it on to
+// OnDrop.
+func (r *Reaper) dropped(sig os.Signal) {
+	r.countStat(func(stats *Stats) { stats.DroppedSignals++ })
+
+	if r.config.OnDrop != nil {
+		r.config.OnDrop(sig)
+	}
+
+} /*  End of method  Reaper.dropped.  */
+
+// Check if we are waiting on a child ourselves (i.e. we launched it).
+func (r *Reaper) owns(pid int) bool {
+	r.mu.Lock()
+	_, ok := r.waiters[pid]
+	r.mu.Unlock()
+
+	return ok
+
+} /*  End of method  Reaper.owns.  */
 
-	go sigChildHandler(ctx, notifications)
+// Check if we need to look at the children in /proc before reaping them.
+func (r *Reaper) inspecting() bool {
+	return r.config.TrackLifetimes
 
-	pid := config.Pid
-	opts := config.Options
+} /*  End of method  Reaper.inspecting.  */
+
+// Peek at the next child to reap and inspect it. Returns the pid to wait
+// on - which is the configured pid if peeking fails, so that the wait
+// itself reports any errors.
+func (r *Reaper) peekInspect(pid int, opts int) (int, time.Duration) {
+	wpid, err := peekChild(pid, opts)
+	if err != nil || wpid <= 0 {
+		return pid, 0
+	}
+
+	return wpid, r.inspect(wpid)
+
+} /*  End of method  Reaper.peekInspect.  */
+
+// Inspect a child about to be reaped, as per its /proc stat.
+func (r *Reaper) inspect(pid int) time.Duration {
+	stat, err := readProcStat(pid)
+	if err != nil {
+		/*  Best effort, it is dying after all.  */
+		level.Debug(r.config.Logger).Log("msg", "no proc stat", "pid", pid, "err", err)
+		return 0
+	}
+
+	return r.inspectStat(stat)
+
+} /*  End of method  Reaper.inspect.  */
+
+// Work out how long a child about to be reaped lived if we didn't launch
+// it (Config.TrackLifetimes), given its /proc stat record.
+func (r *Reaper) inspectStat(stat procStat) time.Duration {
+	if !r.config.TrackLifetimes || r.owns(stat.pid) {
+		return 0
+	}
+
+	age, err := procAge(stat)
+	if err != nil {
+		level.Debug(r.config.Logger).Log("msg", "no start time", "pid", stat.pid, "err", err)
+	}
+	return age
+
+} /*  End of method  Reaper.inspectStat.  */
+
+// Stamp the event with the next sequence number, hand a reap over to
+// whoever launched the child, if anyone is waiting on that pid, and
+// publish the event to all the subscribers. Returns the stamped event.
+func (r *Reaper) dispatch(event ReapEvent) ReapEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	/*  Under the lock, so that events go out in sequence.  */
+	r.seq++
+	event.Seq = r.seq
+
+	/*  Whether we launched the child, its lifetime is then ours to tell.  */
+	launched := false
+
+	if EventReaped == event.Type {
+		event.SelfSignaled = r.selfSignaledLocked(event.Pid, event.Status)
+
+		exited, ok := r.waiters[event.Pid]
+		delete(r.waiters, event.Pid)
+		event.Unexpected = r.unexpectedLocked(event, ok)
+		var lifetime time.Duration
+		if lifetime, launched = r.lifetimeLocked(event.Pid, event.Time); launched {
+			event.Lifetime = lifetime
+		}
+		delete(r.graces, event.Pid)
+		if ok {
+			exited <- event /*  buffered, never blocks.  */
+		}
+		r.notifyWatchersLocked(event)
+
+		if r.failures != nil && OutcomeExited != event.Outcome() {
+			r.failures.add(event)
+		}
+		if r.history != nil {
+			r.history.add(event)
+		}
+		if r.collect {
+			r.collected = append(r.collected, event)
+		}
+	}
+
+	r.publish(event)
+	r.emitLocked(event)
+	r.notifyWebhookLocked(event)
+
+	switch event.Type {
+	case EventReaped:
+		outcome := event.Outcome()
+		r.countStat(func(stats *Stats) {
+			stats.LastReap = event.Time
+			stats.countReaped(outcome)
+			stats.countLifetime(event.Lifetime, launched)
+			stats.countExitCode(event.Status)
+		})
+	case EventContinued:
+		r.countStat(func(stats *Stats) { stats.Continued++ })
+	case EventStopped:
+		r.countStat(func(stats *Stats) { stats.Stopped++ })
+	case EventAdopted:
+		r.countStat(func(stats *Stats) { stats.Adopted++ })
+	}
+
+	return event
+
+} /*  End of method  Reaper.dispatch.  */
+
+// Handle a child that was resumed (only reported with WCONTINUED).
+func (r *Reaper) continued(pid int, wstatus WaitStatus) {
+	event := r.dispatch(ReapEvent{
+		Type:   EventContinued,
+		Pid:    pid,
+		Status: wstatus,
+		Time:   time.Now(),
+	})
+
+	level.Debug(r.config.Logger).Log("msg", "continued", "pid", pid, "seq", event.Seq)
+
+	if r.config.OnContinued != nil {
+		r.config.OnContinued(pid)
+	}
+
+} /*  End of method  Reaper.continued.  */
+
+// Handle a child that was stopped (only reported with WUNTRACED).
+func (r *Reaper) stopped(pid int, wstatus WaitStatus) {
+	event := r.dispatch(ReapEvent{
+		Type:   EventStopped,
+		Pid:    pid,
+		Status: wstatus,
+		Time:   time.Now(),
+	})
+
+	level.Debug(r.config.Logger).Log("msg", "stopped", "pid", pid, "signal", wstatus.StopSignal().String(), "seq", event.Seq)
+
+	if r.config.OnStopped != nil {
+		r.config.OnStopped(pid)
+	}
+
+} /*  End of method  Reaper.stopped.  */
+
+// Report a reaped child, as per the given event (the reaper fills in
+// the rest): dispatch, log and run the callbacks.
+func (r *Reaper) reaped(cbctx context.Context, event ReapEvent) {
+	logger := r.config.Logger
+
+	event.Type, event.Time = EventReaped, time.Now()
+	event = r.dispatch(event)
+
+	lvl := level.Debug
+	if event.Unexpected {
+		lvl = level.Warn
+	}
+
+	if r.logAllowed() {
+		keyvals := append([]interface{}{"msg", "clean up", "pid", event.Pid}, statusKeyvals(event.Status)...)
+		keyvals = append(keyvals, "orphan", event.Orphan, "self_signaled", event.SelfSignaled, "unexpected", event.Unexpected, "seq", event.Seq)
+		if err := lvl(logger).Log(keyvals...); err != nil {
+			r.fallback(event, err)
+		}
+	}
+
+	r.callback(cbctx, event)
+	r.touch()
+
+} /*  End of method  Reaper.reaped.  */
+
+// Sweep up all the waitable children, returns the number reaped.
+//
+// Without WNOHANG in the options, a sweep is meant to block until all the
+// children are gone. A wait4(2) blocked in the kernel can't be cancelled
+// though - and signals won't break it out either, the Go runtime installs
+// its signal handlers with SA_RESTART. So the waits are always WNOHANG
+// and the blocking is done here instead, waiting on the next wakeup (a
+// SIGCHLD) or the context being done, whichever comes first.
+func (r *Reaper) sweep(ctx, cbctx context.Context, wakeups <-chan os.Signal) int {
+	return r.sweepWith(ctx, cbctx, wakeups, r.config.Options)
+
+} /*  End of method  Reaper.sweep.  */
+
+// Sweep with the given wait options rather than the configured ones.
+func (r *Reaper) sweepWith(ctx, cbctx context.Context, wakeups <-chan os.Signal, opts int) int {
+	logger := r.config.Logger
+	pid := r.config.Pid
+	nreaped := 0
+
+	if r.config.TargetGroup > 0 {
+		pid, opts = -r.config.TargetGroup, opts|wNOHANG
+	}
+
+	block := 0 == opts&wNOHANG
+	opts |= wNOHANG
+
+	/*
+	 *  While waiting on children that are alive, keep looking at the
+	 *  tree: orphans get re-parented to us without a SIGCHLD to tell.
+	 */
+	var scan <-chan time.Time
+	if block && (r.config.TrackChildren || r.config.DetectAdoptions) {
+		ticker := time.NewTicker(childScanInterval)
+		defer ticker.Stop()
+		scan = ticker.C
+	}
 
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case sig := <-notifications:
-			level.Debug(logger).Log("msg", "received signal", "signal", sig)
+		var (
+			wstatus WaitStatus
+			rusage  sysUsage
+		)
+
+		if r.limitReached() {
+			/*  Leave the rest be, we are done.  */
+			return nreaped
 		}
 
-		for {
-			var wstatus syscall.WaitStatus
+		var (
+			wpid int
+			err  error
+			idle bool
+		)
+
+		var age time.Duration
+		target := pid
+		switch {
+		case "" != r.config.Cgroup:
+			/*  Have to look at every child before reaping it.  */
+			return nreaped + r.sweepUnclaimed(cbctx, pid)
+
+		case r.config.PeekMode:
+			var claimed bool
+			if target, claimed, idle, err = r.peekUnclaimed(pid, opts); claimed {
+				/*  Someone else's to wait on, reap the rest one by one.  */
+				return nreaped + r.sweepUnclaimed(cbctx, pid)
+			}
+			if r.inspecting() && nil == err && !idle {
+				age = r.inspect(target)
+			}
+
+		case r.claiming():
+			/*  Can't wait on just any child, it may be registered.  */
+			return nreaped + r.sweepUnclaimed(cbctx, pid)
 
+		case r.inspecting():
+			target, age = r.peekInspect(pid, opts)
+		}
+
+		/*
+		 *  Reap 'em, so that zombies don't accumulate.
+		 *  Plants vs. Zombies!!
+		 */
+		if nil == err && !idle {
 			/*
-			 *  Reap 'em, so that zombies don't accumulate.
-			 *  Plants vs. Zombies!!
+			 *  Under the lock, so that a child can't get registered
+			 *  (see Register) between the check and the wait - which
+			 *  never blocks, it is WNOHANG.
 			 */
-			pid, err := syscall.Wait4(pid, &wstatus, opts, nil)
+			r.mu.Lock()
+			if target == pid && len(r.claimed) > 0 {
+				r.mu.Unlock()
+				return nreaped + r.sweepUnclaimed(cbctx, pid)
+			}
+
+			wpid, err = wait4(target, &wstatus, opts, &rusage)
 			for syscall.EINTR == err {
-				pid, err = syscall.Wait4(pid, &wstatus, opts, nil)
+				wpid, err = wait4(target, &wstatus, opts, &rusage)
+			}
+			r.mu.Unlock()
+		}
+
+		switch {
+		case eCHILD == err && target != pid:
+			/*  The peeked at child is gone, someone else got to it.  */
+			continue
+		case eCHILD == err:
+			/*  No children (left) at all.  */
+			return nreaped
+		case err != nil:
+			level.Error(logger).Log("msg", "wait failed", "pid", target, "err", err)
+			r.countStat(func(stats *Stats) { stats.WaitErrors++ })
+			return nreaped
+		case 0 == wpid && block:
+			/*  Wait for the next one to die, or to be told to stop.  */
+			select {
+			case <-ctx.Done():
+				return nreaped
+			case <-wakeups:
+				r.scanChildren()
+				continue
+			case <-scan:
+				r.scanChildren()
+				continue
+			case reaped := <-r.sweeps:
+				/*  One may have died since, have a look right away.  */
+				n := r.sweepWith(ctx, cbctx, nil, opts)
+				nreaped += n
+				reaped <- n
+				continue
+			case <-r.watchdog:
+				/*  Still alive, just waiting.  */
+				r.notifySystemd("WATCHDOG=1")
+				continue
+			case <-r.heartbeat:
+				r.touch()
+				continue
+			}
+		case 0 == wpid:
+			/*
+			 *  WNOHANG: there are children but none of them is ready
+			 *  to be reaped (still running, or its status was taken
+			 *  by someone else). Not a reap, we are done here.
+			 */
+			level.Debug(logger).Log("msg", "no child ready", "pid", target)
+			return nreaped
+		}
+		if wstatus.Continued() {
+			/*  Resumed (SIGCONT), not dead - keep on sweeping.  */
+			r.continued(wpid, wstatus)
+			continue
+		}
+		if wstatus.Stopped() {
+			/*  Job control, not a death either.  */
+			r.stopped(wpid, wstatus)
+			continue
+		}
+
+		r.reaped(cbctx, ReapEvent{
+			Pid:      wpid,
+			Status:   wstatus,
+			Orphan:   r.orphaned(wpid),
+			Lifetime: age,
+			Usage:    usageOf(&rusage),
+		})
+		nreaped++
+
+		if r.config.YieldEvery > 0 && 0 == nreaped%r.config.YieldEvery {
+			runtime.Gosched()
+		}
+	}
+
+} /*  End of method  Reaper.sweepWith.  */
+
+// Sweep one last time on the way out, so that children which died while
+// we were shutting down are still reaped and reported. The sweep is
+// WNOHANG, it doesn't wait on children that are still alive.
+func (r *Reaper) finalSweep(ctx, cbctx context.Context) {
+	if r.config.AssumeAutoReap || r.config.DisableFinalSweep || r.limitReached() {
+		return
+	}
+
+	nreaped := r.sweepWith(ctx, cbctx, nil, r.config.Options|wNOHANG)
+	level.Debug(r.config.Logger).Log("msg", "final sweep", "reaped", nreaped)
+
+} /*  End of method  Reaper.finalSweep.  */
+
+// Work out what to return once the context is done.
+func (r *Reaper) doneErr(ctx context.Context) error {
+	err := ctx.Err()
+	if !r.config.CancelIsClean || context.Canceled != err {
+		return err
+	}
+
+	if cause := context.Cause(ctx); context.Canceled != cause {
+		/*  Cancelled for a reason, pass that on.  */
+		return cause
+	}
+
+	return nil
+
+} /*  End of method  Reaper.doneErr.  */
+
+// Check that we get to reap at all, as per the platform and the config,
+// and become a child subreaper if so configured.
+func (r *Reaper) preflight() error {
+	/*
+	 *  Start the Reaper with configuration options. This allows you to
+	 *  reap processes even if the current pid isn't running as pid 1.
+	 *  So ... use with caution!!
+	 *
+	 *  In most cases, you are better off just using Reap() as that
+	 *  checks if we are running as Pid 1.
+	 */
+	if !supported {
+		return ErrUnsupportedPlatform
+	}
+
+	if err := r.config.Validate(); err != nil {
+		level.Error(r.config.Logger).Log("msg", "invalid config", "err", err)
+		return err
+	}
+
+	if r.config.EnableSubreaper {
+		if err := setSubreaper(); err != nil {
+			return fmt.Errorf("grim reaper: can't become a child subreaper: %w", err)
+		}
+		level.Debug(r.config.Logger).Log("msg", "child subreaper enabled")
+	}
+
+	if !r.config.DisablePid1Check && !r.config.EnableSubreaper {
+		mypid := os.Getpid()
+		if 1 != mypid {
+			return ErrNotPid1
+		}
+
+		/*
+		 *  Pid 1 alright, but make sure we are not the host's init
+		 *  before reaping everything in sight.
+		 */
+		container, hint := inContainer()
+		if !container && !r.config.AllowHostReaping {
+			return ErrHostPid1
+		}
+		level.Debug(r.config.Logger).Log("msg", "container check", "container", container, "hint", hint)
+	}
+
+	return nil
+
+} /*  End of method  Reaper.preflight.  */
+
+// Be a good parent - clean up behind the children.
+func (r *Reaper) reapChildren(ctx context.Context) error {
+	logger := r.config.Logger
+	depth := r.config.NotificationQueueSize
+	if depth <= 0 {
+		depth = 1
+	}
+	var notifications = make(chan os.Signal, depth)
+
+	if r.config.EnableRuntimeTrace {
+		var task *trace.Task
+		ctx, task = trace.NewTask(ctx, "grim-reaper")
+		defer task.End()
+	}
+
+	cbctx := r.callbackContext(ctx)
+
+	r.startCallbacks(cbctx)
+	defer r.stopCallbacks()
+
+	r.startSinks()
+	defer r.stopSinks()
+
+	r.startWebhook()
+	defer r.stopWebhook()
+
+	/*
+	 *  Register for SIGCHLD before saying we are running, so that no
+	 *  child that dies after that goes unnoticed.
+	 */
+	var sigs = make(chan os.Signal, 3)
+	signal.Notify(sigs, append([]os.Signal{sigCHLD}, r.config.SweepOnSignals...)...)
+
+	/*  Keeps going past ctx, for the shutdown (see GracePeriod).  */
+	hctx, stopHandler := context.WithCancel(context.WithoutCancel(ctx))
+	handlerDone := make(chan struct{})
+	r.setHandling(true)
+	go func() {
+		defer close(handlerDone)
+		defer r.setHandling(false)
+		r.sigChildHandler(hctx, sigs, notifications)
+	}()
+
+	/*
+	 *  On the way out, unregister and wait for the signal handler to be
+	 *  gone, so that nothing of ours lingers once Run returns. Whatever
+	 *  signals are left pending are of no interest anymore.
+	 */
+	defer func() {
+		signal.Stop(sigs)
+		stopHandler()
+		<-handlerDone
+
+		for len(notifications) > 0 {
+			<-notifications
+		}
+	}()
+
+	r.setRunning(true)
+	defer r.setRunning(false)
+
+	if r.config.NotifySystemd {
+		watchdog, stop := r.startWatchdog()
+		r.watchdog = watchdog
+		defer stop()
+
+		r.notifySystemd("READY=1")
+		defer r.notifySystemd("STOPPING=1")
+	}
+
+	defer r.startStats(ctx)()
+	defer r.startCheckpoints(ctx)()
+
+	/*  Signs of life for /healthz, see HealthStaleAfter.  */
+	if r.config.HealthStaleAfter > 0 {
+		ticker := time.NewTicker(r.config.HealthStaleAfter / 2)
+		defer ticker.Stop()
+		r.heartbeat = ticker.C
+	}
+
+	var tick <-chan time.Time
+	if r.config.SweepInterval > 0 {
+		ticker := time.NewTicker(r.config.SweepInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	/*  Whatever children we have to start with weren't adopted since.  */
+	r.scanChildren()
+
+	for {
+		/*  A periodic sweep with no SIGCHLD pending, see SweepInterval.  */
+		periodic := false
+
+		/*  Someone waiting on a sweep they asked for, see sweepNow.  */
+		var reaped chan int
+
+		select {
+		case <-ctx.Done():
+			r.setReason(ReasonContext)
+			if r.config.GracePeriod > 0 && !r.config.AssumeAutoReap {
+				r.escalate(ctx, cbctx, notifications)
 			}
+			r.finalSweep(ctx, cbctx)
+			return r.doneErr(ctx)
+		case sig := <-notifications:
+			if r.logAllowed() {
+				level.Debug(logger).Log("msg", "received signal", "signal", sig)
+			}
+		case pid := <-r.exits:
+			r.reapPidfd(cbctx, pid)
+		case reaped = <-r.sweeps:
+		case <-r.watchdog:
+			r.notifySystemd("WATCHDOG=1")
+			continue
+		case <-r.heartbeat:
+			r.touch()
+			continue
+		case <-tick:
+			periodic = 0 == len(notifications)
+		}
 
-			if syscall.ECHILD == err {
-				break
+		r.touch()
+		r.scanChildren()
+
+		if r.config.AssumeAutoReap {
+			/*  Kernel does the reaping, we just watch.  */
+			if reaped != nil {
+				reaped <- 0
 			}
-			level.Debug(logger).Log("msg", "clean up", "pid", pid, "wstatus", wstatus)
+			continue
+		}
+
+		/*  Whoever asked for a sweep wants an answer, not to wait.  */
+		opts := r.config.Options
+		if reaped != nil {
+			opts |= wNOHANG
+		}
+
+		nreaped := 0
+		if !r.config.EnableRuntimeTrace {
+			nreaped = r.sweepWith(ctx, cbctx, notifications, opts)
+		} else {
+			/*  Annotate the sweeps for `go tool trace`.  */
+			trace.WithRegion(ctx, "sweep", func() {
+				nreaped = r.sweepWith(ctx, cbctx, notifications, opts)
+				trace.Logf(ctx, "reaped", "%d", nreaped)
+			})
+		}
+
+		if reaped != nil {
+			reaped <- nreaped
+		}
+
+		if periodic && nreaped > 0 {
+			/*  Their SIGCHLDs never made it to us.  */
+			level.Warn(logger).Log("msg", "periodic sweep reaped children", "count", nreaped)
+			r.countStat(func(stats *Stats) { stats.SweepReaped += uint64(nreaped) })
+		}
+
+		if r.limitReached() {
+			level.Info(logger).Log("msg", "reaped max children, stopping", "max", r.config.MaxReaps)
+			r.setReason(ReasonMaxReaps)
+			return nil
 		}
 	}
-} /*   End of function  reapChildren.  */
+} /*   End of method  Reaper.reapChildren.  */
 
 /*
  *  ======================================================================
@@ -109,39 +1191,146 @@ func Reap(ctx context.Context) error {
 	})
 } /*  End of [exported] function  Reap.  */
 
-// Start Entry point for invoking the reaper code with a specific configuration.
-// The config allows you to bypass the pid 1 checks, so handle with care.
-// The child processes are reaped in the background inside a goroutine.
-func Start(ctx context.Context, config Config) error {
+// New Create a reaper with a specific configuration. The config allows you
+// to bypass the pid 1 checks, so handle with care.
+func New(config Config) *Reaper {
+	if config.Logger == nil && config.Slog != nil {
+		config.Logger = SlogLogger(config.Slog)
+	}
+
+	var filter *levelFilter
 	if config.Logger == nil {
-		var (
-			logger log.Logger
-			lvl    level.Option
-		)
-		if config.Debug {
-			lvl = level.AllowDebug()
-		} else {
-			lvl = level.AllowInfo()
+		filter = &levelFilter{next: log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))}
+		if !config.Debug {
+			filter.set("info")
 		}
-		logger = log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
-		logger = level.NewFilter(logger, lvl)
+
+		var logger log.Logger = filter
 		logger = log.With(logger, "name", "grim-reaper")
 		config.Logger = log.With(logger, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
 	}
 
+	var limiter *logLimiter
+	if config.MaxReapLogsPerSecond > 0 {
+		limiter = &logLimiter{limit: config.MaxReapLogsPerSecond}
+	}
+
+	return &Reaper{
+		config:   config,
+		logLevel: filter,
+		logLimit: limiter,
+		waiters:  make(map[int]chan ReapEvent),
+		watchers: make(map[int][]chan ReapEvent),
+		claimed:  make(map[int]struct{}),
+		signaled: make(map[int]uint64),
+		expected: make(map[int][]int),
+		launched: make(map[int]time.Time),
+		graces:   make(map[int]time.Duration),
+		pidfds:   make(map[int]*os.File),
+		exits:    make(chan int),
+		sweeps:   make(chan chan int),
+		failures: newEventRing(config.FailureHistorySize),
+		history:  newEventRing(config.EventHistorySize),
+		ready:    make(chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+} /*  End of [exported] function  New.  */
+
+// Mark the reaper as done - stops supervision and closes all the
+// subscriber and WaitFor channels.
+func (r *Reaper) finish() {
+	r.doneOnce.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		close(r.done)
+		for _, events := range r.subscribers {
+			close(events)
+		}
+		r.subscribers = nil
+
+		for pid, watchers := range r.watchers {
+			for _, reaped := range watchers {
+				close(reaped)
+			}
+			delete(r.watchers, pid)
+		}
+
+		/*  Wake up the pidfd pollers, their children are on their own.  */
+		for _, pidfd := range r.pidfds {
+			pidfd.SetReadDeadline(time.Now())
+		}
+	})
+
+} /*  End of method  Reaper.finish.  */
+
+// Run Start reaping children with the reaper's configuration. Blocks until
+// the context is cancelled (or Stop is called), at which point supervision
+// of any children launched via Supervise stops as well.
+//
+// The errors it fails with are the sentinels (ErrNotPid1, ErrHostPid1,
+// ErrUnsupportedPlatform, ErrAlreadyRunning and ErrInvalidConfig) or wrap
+// them, so check with errors.Is rather than on the message.
+func (r *Reaper) Run(ctx context.Context) (err error) {
+	r.mu.Lock()
+	started := r.started
+	r.started = true
+	r.mu.Unlock()
+
+	if started {
+		/*  Leave the running (or finished) one be.  */
+		return ErrAlreadyRunning
+	}
+
+	defer r.finish()
+	defer func() { r.setErr(err) }()
+
 	/*
-	 *  Start the Reaper with configuration options. This allows you to
-	 *  reap processes even if the current pid isn't running as pid 1.
-	 *  So ... use with caution!!
-	 *
-	 *  In most cases, you are better off just using Reap() as that
-	 *  checks if we are running as Pid 1.
+	 *  Stop just cancels the context, so that stopping and cancelling
+	 *  take the very same path out - no matter which one comes first
+	 *  (or both at once), the reaper shuts down once and reports the
+	 *  same error and reason.
 	 */
-	if !config.DisablePid1Check {
-		mypid := os.Getpid()
-		if 1 != mypid {
-			return errors.New("grim reaper disabled, pid not 1")
+	ctx, cancel := context.WithCancel(ctx)
+	watcherDone := make(chan struct{})
+	defer func() {
+		cancel()
+		<-watcherDone
+	}()
+
+	go func() {
+		defer close(watcherDone)
+		select {
+		case <-r.stop:
+			cancel()
+		case <-ctx.Done():
 		}
+	}()
+
+	if err := r.preflight(); err != nil {
+		r.setReason(ReasonError)
+		return err
+	}
+
+	/*  A pure observer never waits, it doesn't get in anyone's way.  */
+	if !r.config.AssumeAutoReap {
+		if err := acquire(r); err != nil {
+			level.Error(r.config.Logger).Log("msg", "another reaper is running in this process", "err", err)
+			r.setReason(ReasonError)
+			return err
+		}
+		defer release(r)
+	}
+
+	/*
+	 *  Check if the kernel is already doing our job. The Go runtime
+	 *  installs a SIGCHLD handler of its own at startup, so it takes a
+	 *  signal.Ignore or some non-Go code to set things up that way.
+	 */
+	if auto, err := autoReaping(); err == nil && auto && !r.config.AssumeAutoReap {
+		level.Warn(r.config.Logger).Log("msg", "SIGCHLD is ignored or has SA_NOCLDWAIT set, children are auto-reaped by the kernel - reaping is redundant and may conflict, consider AssumeAutoReap")
 	}
 
 	/*
@@ -149,5 +1338,86 @@ func Start(ctx context.Context, config Config) error {
 	 *  of 'em all, either way we get to play the grim reaper.
 	 *  You will be missed, Terry Pratchett!! RIP
 	 */
-	return reapChildren(ctx, config)
+	if data, err := json.Marshal(r.config); err == nil {
+		level.Info(r.config.Logger).Log("msg", "starting grim reaper", "config", string(data))
+	}
+
+	if r.config.ExpvarName != "" {
+		r.publishExpvar(r.config.ExpvarName)
+	}
+
+	if r.config.ControlSocket != "" {
+		stop, err := r.startControlServer(r.config.ControlSocket)
+		if err != nil {
+			r.setReason(ReasonError)
+			return err
+		}
+		defer stop()
+	}
+
+	if r.config.DebugSignals {
+		defer r.startDebugSignals()()
+	}
+
+	if r.config.HealthAddr != "" {
+		stop, err := r.startHealthServer(r.config.HealthAddr)
+		if err != nil {
+			r.setReason(ReasonError)
+			return err
+		}
+		defer stop()
+	}
+
+	err = r.reapChildren(ctx)
+	if r.config.VerifyCleanShutdown && IsCleanShutdown(err) {
+		if verr := r.verifyCleanShutdown(); verr != nil {
+			err = verr
+		}
+	}
+
+	return err
+} /*  End of [exported] method  Reaper.Run.  */
+
+// Stop Stop the reaper and wait for Run to return. Safe to call any number
+// of times, from any goroutine and concurrently with cancelling the context
+// passed to Run - they all converge on the same shutdown. If Run hasn't
+// been called yet, it returns straight away when it is.
+func (r *Reaper) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	r.mu.Lock()
+	started := r.started
+	r.mu.Unlock()
+
+	if started {
+		<-r.done
+	}
+
+} /*  End of [exported] method  Reaper.Stop.  */
+
+// Shutdown Like Stop, but gives up waiting for Run to return once ctx is
+// done, returning the context's error. The reaper still shuts down in
+// the background. A reaper is good for a single Run, to restart reaping
+// after a shutdown create a new one with New.
+func (r *Reaper) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		r.Stop()
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+} /*  End of [exported] method  Reaper.Shutdown.  */
+
+// Start Entry point for invoking the reaper code with a specific configuration.
+// The config allows you to bypass the pid 1 checks, so handle with care.
+// The child processes are reaped in the background inside a goroutine.
+func Start(ctx context.Context, config Config) error {
+	return New(config).Run(ctx)
 } /*  End of [exported] function  Start.  */
@@ -0,0 +1,104 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// Start a reaper of any of the test binary's children and stop it (and
+// wait for it to be done) once the test is. Fails the test if it doesn't
+// come up. Logs go nowhere, unless the config says otherwise.
+func startTestReaper(t *testing.T, config Config) *Reaper {
+	t.Helper()
+
+	config.Pid = -1
+	config.DisablePid1Check = true
+	config.AllowHostReaping = true
+	if nil == config.Logger {
+		config.Logger = log.NewNopLogger()
+	}
+
+	r := New(config)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- r.Run(context.Background())
+	}()
+
+	select {
+	case err := <-errs:
+		t.Fatalf("reaper failed to start: %v", err)
+	case <-r.ready:
+	}
+
+	t.Cleanup(r.Stop)
+	return r
+
+} /*  End of function  startTestReaper.  */
+
+// Start a child the reaper (rather than exec) is to reap: a shell running
+// the given script. Returns its pid.
+func spawn(t *testing.T, script string) int {
+	t.Helper()
+
+	cmd := exec.Command("/bin/sh", "-c", script)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start %q: %v", script, err)
+	}
+
+	return cmd.Process.Pid
+
+} /*  End of function  spawn.  */
+
+// Wait (for up to 5 seconds) for a condition to hold, failing the test
+// if it doesn't.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+} /*  End of function  eventually.  */
+
+func TestCancelIsClean(t *testing.T) {
+	cause := errors.New("shutting down for maintenance")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	caused, cancelCause := context.WithCancelCause(context.Background())
+	cancelCause(cause)
+
+	expired, cancelTimeout := context.WithTimeout(context.Background(), 0)
+	defer cancelTimeout()
+	<-expired.Done()
+
+	for _, test := range []struct {
+		clean    bool
+		ctx      context.Context
+		expected error
+	}{
+		{false, cancelled, context.Canceled},
+		{true, cancelled, nil},
+		{false, caused, context.Canceled},
+		{true, caused, cause},
+		{false, expired, context.DeadlineExceeded},
+		{true, expired, context.DeadlineExceeded},
+	} {
+		r := New(Config{Pid: -1, CancelIsClean: test.clean})
+		if err := r.doneErr(test.ctx); test.expected != err {
+			t.Errorf("CancelIsClean %v, context done with %v: %v, expected %v", test.clean, context.Cause(test.ctx), err, test.expected)
+		}
+	}
+
+} /*  End of function  TestCancelIsClean.  */
@@ -0,0 +1,90 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+func TestRunCancelIsClean(t *testing.T) {
+	for _, clean := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := New(Config{Pid: -1, DisablePid1Check: true, CancelIsClean: clean, Logger: log.NewNopLogger()})
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- r.Run(ctx)
+		}()
+		<-r.ready
+
+		cancel()
+		err := <-errs
+		if clean && nil != err || !clean && context.Canceled != err {
+			t.Errorf("CancelIsClean %v: run returned %v once cancelled", clean, err)
+		}
+		if !IsCleanShutdown(err) {
+			t.Errorf("CancelIsClean %v: %v not a clean shutdown", clean, err)
+		}
+	}
+
+} /*  End of function  TestRunCancelIsClean.  */
+
+func TestStopAndCancel(t *testing.T) {
+	for idx := 0; idx < 100; idx++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := New(Config{Pid: -1, DisablePid1Check: true, Logger: log.NewNopLogger()})
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- r.Run(ctx)
+		}()
+		<-r.ready
+
+		/*  All at once: a cancel and a couple of stops.  */
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for _, shutdown := range []func(){cancel, r.Stop, r.Stop} {
+			wg.Add(1)
+			go func(shutdown func()) {
+				defer wg.Done()
+				<-start
+				shutdown()
+			}(shutdown)
+		}
+		close(start)
+
+		stopped := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("round %d: stop and cancel deadlocked", idx)
+		}
+
+		/*  Whoever won, the stops only returned once the reaper was done.  */
+		r.Stop()
+		select {
+		case <-r.done:
+		default:
+			t.Fatalf("round %d: stop returned before the reaper was done", idx)
+		}
+		if err := <-errs; context.Canceled != err || context.Canceled != r.Err() {
+			t.Errorf("round %d: run returned %v (err %v), expected %v", idx, err, r.Err(), context.Canceled)
+		}
+		if reason := r.ShutdownReason(); ReasonContext != reason {
+			t.Errorf("round %d: shutdown reason %v, expected %v", idx, reason, ReasonContext)
+		}
+	}
+
+} /*  End of function  TestStopAndCancel.  */
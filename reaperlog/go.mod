@@ -0,0 +1,21 @@
+module github.com/kakkoyun/go-reaper/reaperlog
+
+go 1.25.0
+
+require (
+	github.com/go-kit/log v0.2.0
+	github.com/go-logr/logr v1.4.4
+	github.com/kakkoyun/go-reaper v0.0.0-00010101000000-000000000000
+	github.com/rs/zerolog v1.34.0
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/go-logfmt/logfmt v0.5.1 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
+
+replace github.com/kakkoyun/go-reaper => ../
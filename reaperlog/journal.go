@@ -0,0 +1,124 @@
+package reaperlog
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+/*  Where journald listens for native protocol messages.  */
+const journalSocket = "/run/systemd/journal/socket"
+
+// journalLogger reaper.Logger writing to the systemd journal over its
+// native protocol (see systemd.journal-fields(7)).
+type journalLogger struct {
+	conn       *net.UnixConn
+	identifier string
+}
+
+// Map the reaper's levels to syslog priorities.
+func journalPriority(lvl string) int {
+	switch lvl {
+	case "debug":
+		return 7
+	case "warn":
+		return 4
+	case "error":
+		return 3
+	}
+
+	return 6
+
+} /*  End of function  journalPriority.  */
+
+// Turn a key into a journal field name: upper case letters, digits and
+// underscores only, not starting with an underscore (those are trusted
+// fields, journald sets them itself) or a digit.
+func journalField(key string) string {
+	name := strings.Map(func(c rune) rune {
+		switch {
+		case 'a' <= c && c <= 'z':
+			return c - 'a' + 'A'
+		case 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			return c
+		}
+		return '_'
+	}, key)
+
+	name = strings.TrimLeft(name, "_")
+	if "" == name || ('0' <= name[0] && name[0] <= '9') {
+		name = "F_" + name
+	}
+
+	return name
+
+} /*  End of function  journalField.  */
+
+// Append a field to a journal message. Values with newlines in them use
+// the binary form: the name, a newline, the length as a little endian
+// 64 bit integer and the value itself.
+func appendField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.ContainsRune(value, '\n') {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+
+} /*  End of function  appendField.  */
+
+// Log Implement reaper.Logger. The message becomes MESSAGE, the level
+// PRIORITY (7 for debug down to 3 for error) and every other key value
+// pair a field of its own - so that `journalctl PID=1234` finds a child.
+func (l journalLogger) Log(keyvals ...interface{}) error {
+	parsed := split(keyvals)
+
+	var buf bytes.Buffer
+	appendField(&buf, "MESSAGE", parsed.msg)
+	appendField(&buf, "PRIORITY", fmt.Sprint(journalPriority(parsed.level)))
+	if "" != l.identifier {
+		appendField(&buf, "SYSLOG_IDENTIFIER", l.identifier)
+	}
+
+	for idx := 0; idx < len(parsed.keyvals); idx += 2 {
+		appendField(&buf, journalField(parsed.keyvals[idx].(string)), fmt.Sprint(parsed.keyvals[idx+1]))
+	}
+
+	_, err := l.conn.Write(buf.Bytes())
+	return err
+
+} /*  End of [exported] method  journalLogger.Log.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Journal Log to the systemd journal with proper PRIORITY fields and the
+// key value pairs as fields of their own, tagged with the given
+// SYSLOG_IDENTIFIER (none if empty). Fails if there is no journal to
+// log to. Messages are sent as single datagrams, which the socket
+// buffer size limits - plenty for the reaper's lines.
+func Journal(identifier string) (reaper.Logger, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return journalLogger{conn: conn, identifier: identifier}, nil
+
+} /*  End of [exported] function  Journal.  */
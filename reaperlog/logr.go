@@ -0,0 +1,64 @@
+package reaperlog
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"github.com/go-logr/logr"
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+// logrLogger reaper.Logger on top of a logr.Logger.
+type logrLogger struct {
+	logger logr.Logger
+}
+
+// The key value pairs of a line, less the "err" one if it is the line's
+// error - logr has that logged already.
+func withoutErr(parsed line) []interface{} {
+	if nil == parsed.err {
+		return parsed.keyvals
+	}
+
+	keyvals := make([]interface{}, 0, len(parsed.keyvals))
+	for idx := 0; idx < len(parsed.keyvals); idx += 2 {
+		if "err" != parsed.keyvals[idx] {
+			keyvals = append(keyvals, parsed.keyvals[idx], parsed.keyvals[idx+1])
+		}
+	}
+
+	return keyvals
+
+} /*  End of function  withoutErr.  */
+
+// Log Implement reaper.Logger. logr only knows of info and error lines
+// and of verbosity levels: debug lines are logged at V(1), warnings as
+// info and errors as errors (with the "err" value as the error, if any).
+func (l logrLogger) Log(keyvals ...interface{}) error {
+	parsed := split(keyvals)
+
+	switch parsed.level {
+	case "error":
+		l.logger.Error(parsed.err, parsed.msg, withoutErr(parsed)...)
+	case "debug":
+		l.logger.V(1).Info(parsed.msg, parsed.keyvals...)
+	default:
+		l.logger.Info(parsed.msg, parsed.keyvals...)
+	}
+
+	return nil
+
+} /*  End of [exported] method  logrLogger.Log.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Logr Adapt a logr.Logger (e.g. a controller-runtime one) to the
+// reaper's Logger interface.
+func Logr(logger logr.Logger) reaper.Logger {
+	return logrLogger{logger: logger}
+
+} /*  End of [exported] function  Logr.  */
@@ -0,0 +1,52 @@
+package reaperlog
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-kit/log/level"
+	"github.com/go-logr/logr/funcr"
+)
+
+func TestLogr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Logr(funcr.NewJSON(func(obj string) {
+		buf.WriteString(obj + "\n")
+	}, funcr.Options{Verbosity: 0}))
+
+	level.Debug(logger).Log("msg", "not enabled")
+	level.Warn(logger).Log("msg", "clean up", "pid", 42)
+	level.Error(logger).Log("msg", "wait failed", "err", errBoom, "pid", 43)
+
+	lines := decodeLines(t, buf.String())
+	if 2 != len(lines) {
+		t.Fatalf("logged %d lines, expected 2: %s", len(lines), buf.String())
+	}
+
+	/*  Debug is V(1), warnings are info - and the error is logr's own.  */
+	for idx, expected := range []map[string]interface{}{
+		{"msg": "clean up", "level": 0.0, "pid": 42.0},
+		{"msg": "wait failed", "error": "boom", "pid": 43.0},
+	} {
+		for key, value := range expected {
+			if value != lines[idx][key] {
+				t.Errorf("line %d: %s is %v, expected %v", idx, key, lines[idx][key], value)
+			}
+		}
+	}
+	if _, ok := lines[1]["err"]; ok {
+		t.Errorf("error logged twice: %v", lines[1])
+	}
+
+	/*  With the verbosity up, debug lines make it out too.  */
+	buf.Reset()
+	logger = Logr(funcr.NewJSON(func(obj string) {
+		buf.WriteString(obj + "\n")
+	}, funcr.Options{Verbosity: 1}))
+	level.Debug(logger).Log("msg", "enabled")
+	if lines := decodeLines(t, buf.String()); 1 != len(lines) || 1.0 != lines[0]["level"] {
+		t.Errorf("debug line logged as %v, expected at V(1)", lines)
+	}
+
+} /*  End of function  TestLogr.  */
@@ -0,0 +1,70 @@
+// Package reaperlog Adapters from popular loggers (zap, logr, zerolog) to
+// the grim reaper's Logger interface, so plugging the reaper into an
+// existing service's logging is a one-liner:
+//
+//	config := reaper.Config{
+//		Logger: reaperlog.Zap(logger),
+//	}
+//
+// The reaper's levels (debug, info, warn, error) map to the logger's own,
+// it is then up to the logger which of them make it out - Config.Debug
+// doesn't apply. The "msg" key becomes the message, all the other key
+// value pairs fields.
+//
+// For when the stderr of pid 1 goes nowhere, there are loggers writing
+// to syslog (Syslog) and to the systemd journal (Journal) too.
+package reaperlog
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+
+	"github.com/go-kit/log/level"
+)
+
+// line A log line of the reaper's, split up into its parts.
+type line struct {
+	level   string
+	msg     string
+	err     error
+	keyvals []interface{}
+}
+
+// Split up a log line as the reaper logs it: alternating keys and values,
+// with the level (see the go-kit level package) and the message amongst
+// them. Keys are turned into strings, a missing last value is flagged.
+func split(keyvals []interface{}) line {
+	parsed := line{
+		level:   "info",
+		keyvals: make([]interface{}, 0, len(keyvals)),
+	}
+
+	for idx := 0; idx < len(keyvals); idx += 2 {
+		key := fmt.Sprint(keyvals[idx])
+
+		var value interface{} = "(MISSING)"
+		if idx+1 < len(keyvals) {
+			value = keyvals[idx+1]
+		}
+
+		switch lvl, ok := value.(level.Value); {
+		case ok && "level" == key:
+			parsed.level = lvl.String()
+			continue
+		case "msg" == key && "" == parsed.msg:
+			parsed.msg = fmt.Sprint(value)
+			continue
+		}
+
+		if err, ok := value.(error); ok && "err" == key && nil == parsed.err {
+			parsed.err = err
+		}
+
+		parsed.keyvals = append(parsed.keyvals, key, value)
+	}
+
+	return parsed
+
+} /*  End of function  split.  */
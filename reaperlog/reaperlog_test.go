@@ -0,0 +1,50 @@
+package reaperlog
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-kit/log/level"
+)
+
+// A value that renders by name, like a signal.
+type named int
+
+// String Implement fmt.Stringer.
+func (n named) String() string {
+	return "named"
+
+} /*  End of [exported] method  named.String.  */
+
+var errBoom = errors.New("boom")
+
+func TestSplit(t *testing.T) {
+	for _, test := range []struct {
+		keyvals  []interface{}
+		expected line
+	}{
+		{
+			nil,
+			line{level: "info", keyvals: []interface{}{}},
+		},
+		{
+			[]interface{}{"level", level.WarnValue(), "msg", "clean up", "pid", 42},
+			line{level: "warn", msg: "clean up", keyvals: []interface{}{"pid", 42}},
+		},
+		{
+			[]interface{}{"msg", "wait failed", "err", errBoom, "level", level.ErrorValue(), "msg", "again"},
+			line{level: "error", msg: "wait failed", err: errBoom, keyvals: []interface{}{"err", errBoom, "msg", "again"}},
+		},
+		{
+			[]interface{}{"level", "debug", 7, "seven", "dangling"},
+			line{level: "info", keyvals: []interface{}{"level", "debug", "7", "seven", "dangling", "(MISSING)"}},
+		},
+	} {
+		if parsed := split(test.keyvals); !reflect.DeepEqual(test.expected, parsed) {
+			t.Errorf("split %v: %+v, expected %+v", test.keyvals, parsed, test.expected)
+		}
+	}
+
+} /*  End of function  TestSplit.  */
@@ -0,0 +1,72 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package reaperlog
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"log/syslog"
+
+	"github.com/go-kit/log"
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+// syslogLogger reaper.Logger on top of a *syslog.Writer.
+type syslogLogger struct {
+	writer *syslog.Writer
+}
+
+// Render the key value pairs of a line as logfmt, after the message.
+func logfmt(parsed line) string {
+	var buf bytes.Buffer
+	buf.WriteString(parsed.msg)
+
+	if len(parsed.keyvals) > 0 {
+		buf.WriteByte(' ')
+		log.NewLogfmtLogger(&buf).Log(parsed.keyvals...)
+	}
+
+	return string(bytes.TrimRight(buf.Bytes(), "\n"))
+
+} /*  End of function  logfmt.  */
+
+// Log Implement reaper.Logger. The reaper's levels map to the syslog
+// priorities LOG_DEBUG, LOG_INFO, LOG_WARNING and LOG_ERR, the facility
+// is the writer's.
+func (l syslogLogger) Log(keyvals ...interface{}) error {
+	parsed := split(keyvals)
+	message := logfmt(parsed)
+
+	switch parsed.level {
+	case "debug":
+		return l.writer.Debug(message)
+	case "warn":
+		return l.writer.Warning(message)
+	case "error":
+		return l.writer.Err(message)
+	}
+
+	return l.writer.Info(message)
+
+} /*  End of [exported] method  syslogLogger.Log.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Syslog Adapt a *syslog.Writer to the reaper's Logger interface, for
+// when nobody collects the stderr of pid 1. Lines are logged as the
+// message followed by the other key value pairs in logfmt:
+//
+//	writer, err := syslog.New(syslog.LOG_DAEMON, "grim-reaper")
+//	...
+//	config := reaper.Config{
+//		Logger: reaperlog.Syslog(writer),
+//	}
+func Syslog(writer *syslog.Writer) reaper.Logger {
+	return syslogLogger{writer: writer}
+
+} /*  End of [exported] function  Syslog.  */
@@ -0,0 +1,62 @@
+package reaperlog
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	reaper "github.com/kakkoyun/go-reaper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapLogger reaper.Logger on top of a *zap.Logger.
+type zapLogger struct {
+	logger *zap.Logger
+}
+
+// Map the reaper's levels to zap's.
+func zapLevel(lvl string) zapcore.Level {
+	switch lvl {
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	}
+
+	return zapcore.InfoLevel
+
+} /*  End of function  zapLevel.  */
+
+// Log Implement reaper.Logger.
+func (l zapLogger) Log(keyvals ...interface{}) error {
+	parsed := split(keyvals)
+
+	entry := l.logger.Check(zapLevel(parsed.level), parsed.msg)
+	if entry == nil {
+		/*  Not enabled, no need to build the fields.  */
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, len(parsed.keyvals)/2)
+	for idx := 0; idx < len(parsed.keyvals); idx += 2 {
+		fields = append(fields, zap.Any(parsed.keyvals[idx].(string), parsed.keyvals[idx+1]))
+	}
+
+	entry.Write(fields...)
+	return nil
+
+} /*  End of [exported] method  zapLogger.Log.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Zap Adapt a *zap.Logger to the reaper's Logger interface.
+func Zap(logger *zap.Logger) reaper.Logger {
+	return zapLogger{logger: logger}
+
+} /*  End of [exported] function  Zap.  */
@@ -0,0 +1,63 @@
+package reaperlog
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log/level"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Decode the JSON lines a logger wrote, one map per line.
+func decodeLines(t *testing.T, data string) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, text := range strings.Split(strings.TrimSpace(data), "\n") {
+		if "" == text {
+			continue
+		}
+
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+			t.Fatalf("failed to decode %q: %v", text, err)
+		}
+		lines = append(lines, decoded)
+	}
+
+	return lines
+
+} /*  End of function  decodeLines.  */
+
+func TestZap(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "M", LevelKey: "L", EncodeLevel: zapcore.LowercaseLevelEncoder})
+	logger := Zap(zap.New(zapcore.NewCore(encoder, zapcore.AddSync(&buf), zapcore.InfoLevel)))
+
+	level.Debug(logger).Log("msg", "not enabled")
+	level.Warn(logger).Log("msg", "clean up", "pid", 42, "signal", named(9))
+	level.Error(logger).Log("msg", "wait failed", "err", errBoom)
+	logger.Log("msg", "no level")
+
+	lines := decodeLines(t, buf.String())
+	if 3 != len(lines) {
+		t.Fatalf("logged %d lines, expected 3: %s", len(lines), buf.String())
+	}
+
+	for idx, expected := range []map[string]interface{}{
+		{"L": "warn", "M": "clean up", "pid": 42.0, "signal": "named"},
+		{"L": "error", "M": "wait failed", "err": "boom"},
+		{"L": "info", "M": "no level"},
+	} {
+		for key, value := range expected {
+			if value != lines[idx][key] {
+				t.Errorf("line %d: %s is %v, expected %v", idx, key, lines[idx][key], value)
+			}
+		}
+	}
+
+} /*  End of function  TestZap.  */
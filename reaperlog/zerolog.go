@@ -0,0 +1,68 @@
+package reaperlog
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"time"
+
+	reaper "github.com/kakkoyun/go-reaper"
+	"github.com/rs/zerolog"
+)
+
+// zerologLogger reaper.Logger on top of a zerolog.Logger.
+type zerologLogger struct {
+	logger zerolog.Logger
+}
+
+// Map the reaper's levels to zerolog's.
+func zerologLevel(lvl string) zerolog.Level {
+	switch lvl {
+	case "debug":
+		return zerolog.DebugLevel
+	case "warn":
+		return zerolog.WarnLevel
+	case "error":
+		return zerolog.ErrorLevel
+	}
+
+	return zerolog.InfoLevel
+
+} /*  End of function  zerologLevel.  */
+
+// Log Implement reaper.Logger.
+func (l zerologLogger) Log(keyvals ...interface{}) error {
+	parsed := split(keyvals)
+
+	event := l.logger.WithLevel(zerologLevel(parsed.level))
+	if event == nil {
+		/*  Not enabled.  */
+		return nil
+	}
+
+	for idx := 1; idx < len(parsed.keyvals); idx += 2 {
+		/*  Signals and the like render by name, not as JSON numbers.  */
+		switch value := parsed.keyvals[idx].(type) {
+		case time.Time, time.Duration, error:
+		case fmt.Stringer:
+			parsed.keyvals[idx] = value.String()
+		}
+	}
+
+	event.Fields(parsed.keyvals).Msg(parsed.msg)
+	return nil
+
+} /*  End of [exported] method  zerologLogger.Log.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Zerolog Adapt a zerolog.Logger to the reaper's Logger interface.
+func Zerolog(logger zerolog.Logger) reaper.Logger {
+	return zerologLogger{logger: logger}
+
+} /*  End of [exported] function  Zerolog.  */
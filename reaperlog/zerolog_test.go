@@ -0,0 +1,39 @@
+package reaperlog
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-kit/log/level"
+	"github.com/rs/zerolog"
+)
+
+func TestZerolog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Zerolog(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	level.Debug(logger).Log("msg", "not enabled")
+	level.Warn(logger).Log("msg", "clean up", "pid", 42, "signal", named(9))
+	level.Error(logger).Log("msg", "wait failed", "err", errBoom)
+	logger.Log("msg", "no level")
+
+	lines := decodeLines(t, buf.String())
+	if 3 != len(lines) {
+		t.Fatalf("logged %d lines, expected 3: %s", len(lines), buf.String())
+	}
+
+	/*  Stringers by name, not as numbers.  */
+	for idx, expected := range []map[string]interface{}{
+		{"level": "warn", "message": "clean up", "pid": 42.0, "signal": "named"},
+		{"level": "error", "message": "wait failed", "err": "boom"},
+		{"level": "info", "message": "no level"},
+	} {
+		for key, value := range expected {
+			if value != lines[idx][key] {
+				t.Errorf("line %d: %s is %v, expected %v", idx, key, lines[idx][key], value)
+			}
+		}
+	}
+
+} /*  End of function  TestZerolog.  */
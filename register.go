@@ -0,0 +1,171 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"syscall"
+
+	"github.com/go-kit/log/level"
+)
+
+// Check if any children are registered, i.e. to be left alone.
+func (r *Reaper) claiming() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.claimed) > 0
+
+} /*  End of method  Reaper.claiming.  */
+
+// Reap a given child unless it is registered. The check and the wait are
+// done under the lock, so the child can't get registered in between.
+// Returns false if the child wasn't reaped.
+func (r *Reaper) reapUnclaimed(pid int) (WaitStatus, *sysUsage, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		wstatus WaitStatus
+		rusage  sysUsage
+	)
+	if _, ok := r.claimed[pid]; ok {
+		return wstatus, nil, false
+	}
+
+	wpid, err := wait4(pid, &wstatus, wNOHANG, &rusage)
+	for syscall.EINTR == err {
+		wpid, err = wait4(pid, &wstatus, wNOHANG, &rusage)
+	}
+
+	/*  On error, it's gone already - someone else got to it.  */
+	return wstatus, &rusage, nil == err && wpid == pid
+
+} /*  End of method  Reaper.reapUnclaimed.  */
+
+// Peek at the next child that changed state (without consuming its
+// status) and check if it is registered. Returns the child's pid to wait
+// on, whether it is registered and whether there was no child to peek at
+// (i.e. none ready). If peeking isn't possible (but there are children),
+// falls back to the configured pid - unless children are registered.
+func (r *Reaper) peekUnclaimed(pid int, opts int) (int, bool, bool, error) {
+	next, err := peekChild(pid, opts)
+	switch {
+	case eCHILD == err:
+		return pid, false, false, err
+	case err != nil:
+		level.Debug(r.config.Logger).Log("msg", "peek failed", "err", err)
+		return pid, r.claiming(), false, nil
+	case 0 == next:
+		return pid, false, true, nil
+	}
+
+	r.mu.Lock()
+	_, claimed := r.claimed[next]
+	r.mu.Unlock()
+
+	return next, claimed, false, nil
+
+} /*  End of method  Reaper.peekUnclaimed.  */
+
+// Check if a child matches the pid to wait for, as per wait4(2).
+func waitMatches(stat procStat, pid int) bool {
+	switch {
+	case pid > 0:
+		return stat.pid == pid
+	case pid == 0:
+		return stat.pgrp == getpgrp()
+	case pid < -1:
+		return stat.pgrp == -pid
+	}
+
+	return true
+
+} /*  End of function  waitMatches.  */
+
+// Sweep up the dead children, except for the registered ones (and those
+// outside of Config.Cgroup). A wait on any child (pid -1) could take a
+// registered child's status, so instead the zombies among our children
+// are looked up in /proc and waited on one by one. Never blocks. Returns
+// the number reaped.
+func (r *Reaper) sweepUnclaimed(cbctx context.Context, pid int) int {
+	logger := r.config.Logger
+
+	found, err := zombies()
+	if err != nil {
+		/*  Better leave them be than steal someone's status.  */
+		level.Warn(logger).Log("msg", "can't look up zombies, not reaping while children are registered", "err", err)
+		return 0
+	}
+
+	nreaped := 0
+	for _, stat := range found {
+		if r.limitReached() {
+			break
+		}
+		if !waitMatches(stat, pid) {
+			continue
+		}
+
+		report := r.inCgroup(stat.pid)
+		if !report && !r.config.CgroupReportOnly {
+			/*  Someone else's, leave it for them.  */
+			continue
+		}
+
+		wstatus, rusage, ok := r.reapUnclaimed(stat.pid)
+		if !ok {
+			continue
+		}
+
+		if !report {
+			r.countStat(func(stats *Stats) { stats.Unreported++ })
+			continue
+		}
+
+		r.reaped(cbctx, ReapEvent{
+			Pid:      stat.pid,
+			Status:   wstatus,
+			Orphan:   r.orphaned(stat.pid),
+			Lifetime: r.inspectStat(stat),
+			Usage:    usageOf(rusage),
+		})
+		nreaped++
+	}
+
+	return nreaped
+
+} /*  End of method  Reaper.sweepUnclaimed.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Register Tell the reaper to leave the child with the given pid alone,
+// so that whoever started it (e.g. exec.Cmd.Wait) still gets to collect
+// its exit status. Register the child as soon as it has been started,
+// the reaper may otherwise reap it first if it dies straight away.
+//
+// While any children are registered, the reaper no longer waits on any
+// child (pid -1) but reaps the other dead children one by one, as found
+// in /proc - without /proc, no children get reaped meanwhile.
+func (r *Reaper) Register(pid int) {
+	r.mu.Lock()
+	r.claimed[pid] = struct{}{}
+	r.mu.Unlock()
+
+} /*  End of [exported] method  Reaper.Register.  */
+
+// Unregister Undo Register, once the child's status has been collected.
+// Should the child (still) be a zombie, the reaper reaps it on its next
+// sweep.
+func (r *Reaper) Unregister(pid int) {
+	r.mu.Lock()
+	delete(r.claimed, pid)
+	delete(r.signaled, pid)
+	r.mu.Unlock()
+
+} /*  End of [exported] method  Reaper.Unregister.  */
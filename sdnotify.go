@@ -0,0 +1,69 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// Send a state notification to systemd (see sd_notify(3)), a no-op when
+// not running under systemd (no NOTIFY_SOCKET). An abstract socket name
+// ('@' prefixed) is taken care of by the net package.
+func (r *Reaper) notifySystemd(state string) {
+	path := os.Getenv("NOTIFY_SOCKET")
+	if "" == path {
+		return
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		level.Warn(r.config.Logger).Log("msg", "failed to notify systemd", "state", state, "err", err)
+		return
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		level.Warn(r.config.Logger).Log("msg", "failed to notify systemd", "state", state, "err", err)
+	}
+
+} /*  End of method  Reaper.notifySystemd.  */
+
+// The interval to send watchdog heartbeats at, half the watchdog timeout
+// systemd set (WATCHDOG_USEC) as sd_watchdog_enabled(3) recommends. Zero
+// if the watchdog isn't enabled for us.
+func watchdogInterval() time.Duration {
+	if pid := os.Getenv("WATCHDOG_PID"); "" != pid && strconv.Itoa(os.Getpid()) != pid {
+		/*  Meant for some other process.  */
+		return 0
+	}
+
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2
+
+} /*  End of function  watchdogInterval.  */
+
+// Start the systemd watchdog heartbeats, if systemd asked for them.
+// Returns the channel the reap loop picks the heartbeats up from (nil if
+// there are none to send) and a func that stops them.
+func (r *Reaper) startWatchdog() (<-chan time.Time, func()) {
+	interval := watchdogInterval()
+	if 0 == interval {
+		return nil, func() {}
+	}
+
+	level.Debug(r.config.Logger).Log("msg", "sending systemd watchdog heartbeats", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	return ticker.C, ticker.Stop
+
+} /*  End of method  Reaper.startWatchdog.  */
@@ -0,0 +1,64 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+// Receive the reap event for a pid, failing the test if none comes
+// within 5 seconds.
+func reapOf(t *testing.T, events <-chan ReapEvent, pid int) ReapEvent {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if pid == event.Pid && EventReaped == event.Type {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for pid %d to be reaped", pid)
+		}
+	}
+
+} /*  End of function  reapOf.  */
+
+func TestSelfSignaled(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	pid := spawn(t, "exec sleep 10")
+	event, err := r.Terminate(pid, time.Second)
+	if err != nil {
+		t.Fatalf("failed to terminate %d: %v", pid, err)
+	}
+	if !event.SelfSignaled || !event.Status.Signaled() || syscall.SIGTERM != event.Status.Signal() {
+		t.Errorf("terminated child reaped with status %v, self signaled %v", event.Status, event.SelfSignaled)
+	}
+
+	events, unsubscribe := r.Subscribe(16)
+	defer unsubscribe()
+
+	/*  Killed by someone else, or by a signal other than ours.  */
+	pid = spawn(t, "exec sleep 10")
+	syscall.Kill(pid, syscall.SIGTERM)
+	if event := reapOf(t, events, pid); event.SelfSignaled {
+		t.Errorf("child killed from outside tagged as self signaled")
+	}
+
+	/*  Ignored by default, so no need to trap it.  */
+	pid = spawn(t, "exec sleep 10")
+	if err := r.Signal(pid, syscall.SIGURG); err != nil {
+		t.Fatalf("failed to signal %d: %v", pid, err)
+	}
+	syscall.Kill(pid, syscall.SIGKILL)
+	if event := reapOf(t, events, pid); event.SelfSignaled {
+		t.Errorf("child sent a SIGURG but killed by a SIGKILL tagged as self signaled")
+	}
+
+} /*  End of function  TestSelfSignaled.  */
@@ -0,0 +1,109 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	selfTestExitCode = 42
+	selfTestTimeout  = 5 * time.Second
+
+	/*  Set for the canary, which is us re-executed.  */
+	selfTestEnv = "REAPER_SELF_TEST_CANARY"
+)
+
+// The canary end of the self test: a re-executed binary (whichever one
+// imports this package) exits straight away with a known exit code,
+// before its own init or main get to run.
+func init() {
+	if "1" == os.Getenv(selfTestEnv) {
+		os.Exit(selfTestExitCode)
+	}
+
+} /*  End of function  init.  */
+
+// The canary child for the self test: this very executable, exiting
+// straight away with a known exit code (see init). Falls back to
+// /bin/sh doing the same if the executable can't be found.
+func selfTestSpec() ChildSpec {
+	spec := ChildSpec{
+		Env: []string{selfTestEnv + "=1"},
+		Dir: "/",
+
+		ExpectedExitCodes: []int{selfTestExitCode},
+	}
+
+	if exe, err := os.Executable(); err == nil {
+		spec.Path = exe
+		spec.Args = []string{exe}
+	} else {
+		spec.Path = "/bin/sh"
+		spec.Args = []string{"sh", "-c", fmt.Sprintf("exit %d", selfTestExitCode)}
+	}
+
+	return spec
+
+} /*  End of function  selfTestSpec.  */
+
+// Stop waiting on a child we launched.
+func (r *Reaper) forget(pid int) {
+	r.mu.Lock()
+	delete(r.waiters, pid)
+	delete(r.launched, pid)
+	r.mu.Unlock()
+
+} /*  End of method  Reaper.forget.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// SelfTest Check that reaping actually works in this environment: forks a
+// canary child (the running executable re-executed, or /bin/sh if that
+// can't be found, exiting with a known exit code) and waits for the
+// reaper to reap it with that exit code. Fails if that doesn't happen
+// before the context is done (or within 5 seconds if the context has no
+// deadline) - e.g. if wait4 or SIGCHLD delivery is broken by seccomp or
+// some unusual sandbox. The reaper must be running (see Run).
+func (r *Reaper) SelfTest(ctx context.Context) error {
+	r.statsMu.Lock()
+	running := r.running
+	r.statsMu.Unlock()
+
+	if !running {
+		return fmt.Errorf("grim reaper self test: %w", ErrNotRunning)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, selfTestTimeout)
+		defer cancel()
+	}
+
+	pid, exited, err := r.launch(selfTestSpec())
+	if err != nil {
+		return fmt.Errorf("grim reaper self test: canary failed to start: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		r.forget(pid)
+		return fmt.Errorf("grim reaper self test: canary pid %d not reaped: %v", pid, ctx.Err())
+
+	case event := <-exited:
+		if !event.Status.Exited() || selfTestExitCode != event.Status.ExitStatus() {
+			return fmt.Errorf("grim reaper self test: canary pid %d reaped with wstatus %d, expected exit code %d", pid, event.Status, selfTestExitCode)
+		}
+	}
+
+	return nil
+
+} /*  End of [exported] method  Reaper.SelfTest.  */
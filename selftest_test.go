@@ -0,0 +1,34 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	if err := r.SelfTest(context.Background()); err != nil {
+		t.Fatalf("self test failed: %v", err)
+	}
+
+	spec := selfTestSpec()
+	if selfTestEnv+"=1" != spec.Env[0] || spec.Path != spec.Args[0] {
+		t.Errorf("canary spec %+v, expected the test binary re-executed", spec)
+	}
+
+} /*  End of function  TestSelfTest.  */
+
+func TestSelfTestNotRunning(t *testing.T) {
+	r := New(Config{Pid: -1})
+
+	if err := r.SelfTest(context.Background()); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("self test of a reaper that isn't running: %v, expected ErrNotRunning", err)
+	}
+
+} /*  End of function  TestSelfTestNotRunning.  */
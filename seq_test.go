@@ -0,0 +1,53 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"sync"
+	"testing"
+)
+
+func TestSeqGapless(t *testing.T) {
+	const children = 20
+
+	var mu sync.Mutex
+	var seqs []uint64
+
+	r := startTestReaper(t, Config{
+		OnReap: func(event ReapEvent) {
+			mu.Lock()
+			seqs = append(seqs, event.Seq)
+			mu.Unlock()
+		},
+	})
+
+	/*  Never read until the end, so it drops all but the first one.  */
+	full, _ := r.Subscribe(1)
+
+	for idx := 0; idx < children; idx++ {
+		spawn(t, "exit 0")
+	}
+	eventually(t, "the reaps", func() bool { return children == r.Stats().Reaped })
+	r.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for idx, seq := range seqs {
+		if uint64(idx+1) != seq {
+			t.Fatalf("reap %d got seq %d, expected the reaper's own events gapless: %v", idx+1, seq, seqs)
+		}
+	}
+	if children != len(seqs) {
+		t.Errorf("got %d reap events, expected %d", len(seqs), children)
+	}
+
+	/*  The subscriber can tell what it missed by the gap.  */
+	got := drain(full)
+	if 1 != len(got) || 1 != got[0].Seq || children-1 != r.Stats().DroppedEvents {
+		t.Errorf("full subscriber got %+v with %d dropped, expected just seq 1", got, r.Stats().DroppedEvents)
+	}
+
+} /*  End of function  TestSeqGapless.  */
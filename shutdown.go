@@ -0,0 +1,86 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+// ShutdownReason Why the reaper stopped.
+type ShutdownReason int
+
+const (
+	// ReasonNone The reaper hasn't stopped (yet).
+	ReasonNone ShutdownReason = iota
+
+	// ReasonContext The context passed to Run is done.
+	ReasonContext
+
+	// ReasonMaxReaps The reaper reaped Config.MaxReaps children.
+	ReasonMaxReaps
+
+	// ReasonError The reaper failed to start (see the error from Run).
+	ReasonError
+)
+
+// String Name of the shutdown reason.
+func (s ShutdownReason) String() string {
+	switch s {
+	case ReasonNone:
+		return "none"
+	case ReasonContext:
+		return "context"
+	case ReasonMaxReaps:
+		return "max-reaps"
+	case ReasonError:
+		return "error"
+	}
+
+	return "unknown"
+
+} /*  End of [exported] method  ShutdownReason.String.  */
+
+// Record why the reaper stopped.
+func (r *Reaper) setReason(reason ShutdownReason) {
+	r.statsMu.Lock()
+	r.reason = reason
+	r.statsMu.Unlock()
+
+} /*  End of method  Reaper.setReason.  */
+
+// Record the error Run returned.
+func (r *Reaper) setErr(err error) {
+	r.statsMu.Lock()
+	r.err = err
+	r.statsMu.Unlock()
+
+} /*  End of method  Reaper.setErr.  */
+
+// Check if the reaper reaped as many children as it is allowed to.
+func (r *Reaper) limitReached() bool {
+	return r.config.MaxReaps > 0 && r.Stats().Reaped >= uint64(r.config.MaxReaps)
+
+} /*  End of method  Reaper.limitReached.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// ShutdownReason Get the reason the reaper stopped, ReasonNone while it is
+// still running (or hasn't run yet).
+func (r *Reaper) ShutdownReason() ShutdownReason {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	return r.reason
+
+} /*  End of [exported] method  Reaper.ShutdownReason.  */
+
+// Err Get the error Run returned, nil while it is still running (or
+// hasn't run yet) and after a clean shutdown. See IsCleanShutdown,
+// IsFatal and IsRetryable for what to make of it.
+func (r *Reaper) Err() error {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	return r.err
+
+} /*  End of [exported] method  Reaper.Err.  */
@@ -0,0 +1,300 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+const (
+	defaultSinkQueueSize = 64
+
+	/*  How long a reader gets to take an event off our hands.  */
+	sinkWriteTimeout = 1 * time.Second
+)
+
+// Backoff between attempts to (re)connect to an event sink.
+var sinkBackoff = Backoff{Initial: 100 * time.Millisecond, Max: 30 * time.Second}
+
+// sinkConn Connection to an event sink, a unix socket or a FIFO.
+type sinkConn interface {
+	io.WriteCloser
+	SetWriteDeadline(t time.Time) error
+}
+
+// eventSink Writes reap events, one JSON object (or logfmt line, see
+// Config.AuditFormat) per line, to a unix socket, FIFO or file. The
+// events are handed over via a bounded queue and written out by a
+// goroutine of its own, so a slow, stalled or vanished reader never
+// holds up the reap loop - at worst events get dropped (and counted).
+type eventSink struct {
+	name   string
+	dial   func() (sinkConn, error)
+	encode func(ReapEvent) ([]byte, error)
+	events chan ReapEvent
+	wg     sync.WaitGroup
+}
+
+// eventRecord How a reap event is rendered for the event sinks.
+type eventRecord struct {
+	Seq          uint64    `json:"seq"`
+	Type         string    `json:"type"`
+	Pid          int       `json:"pid"`
+	Time         time.Time `json:"time"`
+	Outcome      string    `json:"outcome,omitempty"`
+	ExitCode     *int      `json:"exit_code,omitempty"`
+	Signal       string    `json:"signal,omitempty"`
+	CoreDumped   bool      `json:"core_dumped,omitempty"`
+	RawStatus    uint32    `json:"raw_status"`
+	Orphan       bool      `json:"orphan,omitempty"`
+	SelfSignaled bool      `json:"self_signaled,omitempty"`
+	Unexpected   bool      `json:"unexpected,omitempty"`
+	Lifetime     string    `json:"lifetime,omitempty"`
+	UserTime     string    `json:"user_time,omitempty"`
+	SystemTime   string    `json:"system_time,omitempty"`
+	MaxRSS       int64     `json:"max_rss,omitempty"`
+	MinorFaults  int64     `json:"minor_faults,omitempty"`
+	MajorFaults  int64     `json:"major_faults,omitempty"`
+	Comm         string    `json:"comm,omitempty"`
+	Cmdline      []string  `json:"cmdline,omitempty"`
+}
+
+// Render a reap event for the event sinks (and the debug page).
+func recordOf(event ReapEvent) eventRecord {
+	record := eventRecord{
+		Seq:          event.Seq,
+		Type:         event.Type.String(),
+		Pid:          event.Pid,
+		Time:         event.Time,
+		RawStatus:    rawStatus(event.Status),
+		Orphan:       event.Orphan,
+		SelfSignaled: event.SelfSignaled,
+		Unexpected:   event.Unexpected,
+		Comm:         event.Comm,
+		Cmdline:      event.Cmdline,
+	}
+
+	if EventReaped == event.Type {
+		record.Outcome = event.Outcome().String()
+		if event.Status.Signaled() {
+			record.Signal = event.Status.Signal().String()
+			record.CoreDumped = event.Status.CoreDump()
+		} else {
+			code := event.Status.ExitStatus()
+			record.ExitCode = &code
+		}
+
+		if event.Usage.UserTime > 0 {
+			record.UserTime = event.Usage.UserTime.String()
+		}
+		if event.Usage.SystemTime > 0 {
+			record.SystemTime = event.Usage.SystemTime.String()
+		}
+		record.MaxRSS = event.Usage.MaxRSS
+		record.MinorFaults = event.Usage.MinorFaults
+		record.MajorFaults = event.Usage.MajorFaults
+	}
+
+	if EventStopped == event.Type {
+		record.Signal = event.Status.StopSignal().String()
+	}
+
+	if event.Lifetime > 0 {
+		record.Lifetime = event.Lifetime.String()
+	}
+
+	return record
+
+} /*  End of function  recordOf.  */
+
+// Render a reap event as a line of JSON.
+func marshalEvent(event ReapEvent) ([]byte, error) {
+	data, err := json.Marshal(recordOf(event))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+
+} /*  End of function  marshalEvent.  */
+
+// writerConn A plain io.Writer as a sink connection, with no deadlines
+// and nothing to close (it isn't ours).
+type writerConn struct {
+	io.Writer
+}
+
+func (writerConn) Close() error                       { return nil }
+func (writerConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// Connect to a listening unix (stream) socket.
+func dialSocket(path string) func() (sinkConn, error) {
+	return func() (sinkConn, error) {
+		conn, err := net.DialTimeout("unix", path, sinkWriteTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return conn.(*net.UnixConn), nil
+	}
+
+} /*  End of function  dialSocket.  */
+
+// Open a FIFO for writing. Opening it non-blocking fails (ENXIO) rather
+// than hanging while there is no reader, and gets the FIFO onto the
+// runtime poller so that write deadlines work.
+func openFIFO(path string) func() (sinkConn, error) {
+	return func() (sinkConn, error) {
+		return os.OpenFile(path, os.O_WRONLY|oNONBLOCK, 0)
+	}
+
+} /*  End of function  openFIFO.  */
+
+// Write out the queued events until the queue is closed. Any write error
+// (EPIPE or ECONNRESET once the reader went away, a timeout when it
+// stalled) drops the connection, as a partially written line leaves the
+// stream in an unknown state - we then reconnect with backoff. A failure
+// is logged once, until we are connected again.
+func (r *Reaper) runSink(sink *eventSink) {
+	logger := r.config.Logger
+	backoff := sinkBackoff.withDefaults()
+
+	var (
+		conn    sinkConn
+		failing bool
+		retry   time.Time
+		delay   = backoff.Initial
+	)
+
+	fail := func(msg string, err error) {
+		if !failing {
+			level.Warn(logger).Log("msg", msg, "sink", sink.name, "err", err)
+		}
+		failing = true
+		retry = time.Now().Add(delay)
+		delay = backoff.next(delay)
+	}
+
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for event := range sink.events {
+		if conn == nil {
+			if time.Now().Before(retry) {
+				/*  Still backing off, no one to hand it to.  */
+				r.countStat(func(stats *Stats) { stats.DroppedSinkEvents++ })
+				continue
+			}
+
+			var err error
+			if conn, err = sink.dial(); err != nil {
+				fail("event sink unavailable", err)
+				conn = nil
+				r.countStat(func(stats *Stats) { stats.DroppedSinkEvents++ })
+				continue
+			}
+
+			if failing {
+				level.Info(logger).Log("msg", "event sink reconnected", "sink", sink.name)
+			}
+			failing, delay = false, backoff.Initial
+		}
+
+		data, err := sink.encode(event)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to encode event", "sink", sink.name, "err", err)
+			continue
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(sinkWriteTimeout))
+		if _, err := conn.Write(data); err != nil {
+			fail("event sink write failed", err)
+			conn.Close()
+			conn = nil
+			r.countStat(func(stats *Stats) { stats.DroppedSinkEvents++ })
+		}
+	}
+
+} /*  End of method  Reaper.runSink.  */
+
+// Start the configured event sinks.
+func (r *Reaper) startSinks() {
+	size := r.config.EventQueueSize
+	if size <= 0 {
+		size = defaultSinkQueueSize
+	}
+
+	var sinks []*eventSink
+	if r.config.EventSocket != "" {
+		sinks = append(sinks, &eventSink{name: r.config.EventSocket, dial: dialSocket(r.config.EventSocket)})
+	}
+	if r.config.EventFIFO != "" {
+		sinks = append(sinks, &eventSink{name: r.config.EventFIFO, dial: openFIFO(r.config.EventFIFO)})
+	}
+	if w := r.config.EventWriter; w != nil {
+		dial := func() (sinkConn, error) { return writerConn{w}, nil }
+		sinks = append(sinks, &eventSink{name: "writer", dial: dial})
+	}
+	if path := r.config.AuditFile; path != "" {
+		sink := &eventSink{name: path, dial: openAudit(path, r.config.AuditMaxSize, r.config.AuditMaxBackups)}
+		if "logfmt" == r.config.AuditFormat {
+			sink.encode = logfmtEvent
+		}
+		sinks = append(sinks, sink)
+	}
+
+	for _, sink := range sinks {
+		if nil == sink.encode {
+			sink.encode = marshalEvent
+		}
+		sink.events = make(chan ReapEvent, size)
+		sink.wg.Add(1)
+		go func(sink *eventSink) {
+			defer sink.wg.Done()
+			r.runSink(sink)
+		}(sink)
+	}
+
+	r.mu.Lock()
+	r.sinks = sinks
+	r.mu.Unlock()
+
+} /*  End of method  Reaper.startSinks.  */
+
+// Stop the event sinks, giving them the chance to write out what's queued.
+func (r *Reaper) stopSinks() {
+	r.mu.Lock()
+	sinks := r.sinks
+	r.sinks = nil
+	r.mu.Unlock()
+
+	for _, sink := range sinks {
+		close(sink.events)
+		sink.wg.Wait()
+	}
+
+} /*  End of method  Reaper.stopSinks.  */
+
+// Hand an event to the event sinks, never blocking: an event that doesn't
+// fit in a sink's queue is dropped. Caller holds the lock.
+func (r *Reaper) emitLocked(event ReapEvent) {
+	for _, sink := range r.sinks {
+		select {
+		case sink.events <- event:
+		default:
+			r.countStat(func(stats *Stats) { stats.DroppedSinkEvents++ })
+		}
+	}
+
+} /*  End of method  Reaper.emitLocked.  */
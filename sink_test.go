@@ -0,0 +1,79 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// A reader that stalls on the first event, until released - and then
+// goes away (EPIPE), taking whatever is written after that.
+type stallingWriter struct {
+	syncBuffer
+
+	stalled  chan struct{}
+	released chan struct{}
+	once     bool
+}
+
+// Write Implement io.Writer.
+func (w *stallingWriter) Write(data []byte) (int, error) {
+	if !w.once {
+		w.once = true
+		close(w.stalled)
+		<-w.released
+		return 0, syscall.EPIPE
+	}
+
+	return w.syncBuffer.Write(data)
+
+} /*  End of [exported] method  stallingWriter.Write.  */
+
+func TestSinkStalled(t *testing.T) {
+	const children = 10
+
+	saved := sinkBackoff
+	sinkBackoff = Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	t.Cleanup(func() { sinkBackoff = saved })
+
+	w := &stallingWriter{stalled: make(chan struct{}), released: make(chan struct{})}
+	r := startTestReaper(t, Config{EventWriter: w, EventQueueSize: 2})
+	t.Cleanup(func() {
+		select {
+		case <-w.released:
+		default:
+			close(w.released)
+		}
+	})
+
+	spawn(t, "exit 0")
+	select {
+	case <-w.stalled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the event to be written")
+	}
+
+	/*  The reaping goes on, whatever doesn't fit in the queue is dropped.  */
+	for idx := 0; idx < children; idx++ {
+		spawn(t, "exit 0")
+	}
+	eventually(t, "the reaps", func() bool { return 1+children == r.Stats().Reaped })
+	eventually(t, "the drops", func() bool { return r.Stats().DroppedSinkEvents >= children-2 })
+
+	/*  The reader goes away - the next events get through again.  */
+	close(w.released)
+	eventually(t, "the failed write", func() bool { return r.Stats().DroppedSinkEvents >= children-1 })
+	time.Sleep(10 * time.Millisecond)
+
+	pid := spawn(t, "exit 0")
+	eventually(t, "the event after the reconnect", func() bool {
+		return strings.Contains(w.String(), fmt.Sprintf(`"pid":%d,`, pid))
+	})
+
+} /*  End of function  TestSinkStalled.  */
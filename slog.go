@@ -0,0 +1,94 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-kit/log/level"
+)
+
+// slogLogger Logger that hands the reaper's log lines to a *slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// Map a go-kit level (as added by level.Debug and friends) to slog's.
+func slogLevel(value interface{}) (slog.Level, bool) {
+	lvl, ok := value.(level.Value)
+	if !ok {
+		return 0, false
+	}
+
+	switch lvl.String() {
+	case "debug":
+		return slog.LevelDebug, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	}
+
+	return slog.LevelInfo, true
+
+} /*  End of function  slogLevel.  */
+
+// Log Implement Logger. The "level" and "msg" keys become the record's
+// level and message, every other key value pair an attribute.
+func (l slogLogger) Log(keyvals ...interface{}) error {
+	var (
+		lvl   = slog.LevelInfo
+		msg   string
+		attrs = make([]slog.Attr, 0, len(keyvals)/2)
+	)
+
+	for idx := 0; idx < len(keyvals); idx += 2 {
+		key := fmt.Sprint(keyvals[idx])
+
+		var value interface{} = "(MISSING)"
+		if idx+1 < len(keyvals) {
+			value = keyvals[idx+1]
+		}
+
+		if "level" == key {
+			if mapped, ok := slogLevel(value); ok {
+				lvl = mapped
+				continue
+			}
+		}
+		if "msg" == key && "" == msg {
+			msg = fmt.Sprint(value)
+			continue
+		}
+
+		/*  Signals and the like render by name, as in logfmt.  */
+		attr := slog.Any(key, value)
+		if stringer, ok := value.(fmt.Stringer); ok && slog.KindAny == attr.Value.Kind() {
+			attr = slog.String(key, stringer.String())
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	l.logger.LogAttrs(context.Background(), lvl, msg, attrs...)
+	return nil
+
+} /*  End of [exported] method  slogLogger.Log.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// SlogLogger Adapt a *slog.Logger to the reaper's Logger interface, for
+// the users of the standard library's structured logging. Levels map from
+// the reaper's (debug, info, warn, error) to slog's, which level makes it
+// out is then up to the logger's handler - Config.Debug doesn't apply.
+func SlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+
+} /*  End of [exported] function  SlogLogger.  */
@@ -0,0 +1,298 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Stats Counters describing what the reaper has been up to.
+type Stats struct {
+	// Started and Uptime When the reaper started running and for how
+	// long it has been, as of the snapshot. Zero until Run is called,
+	// the uptime stops counting once Run returns.
+	Started time.Time
+	Uptime  time.Duration
+
+	// Reaped Number of children reaped.
+	Reaped uint64
+
+	// Exited, Failed, Signaled and CoreDumped Breakdown of the reaped
+	// children by outcome.
+	Exited     uint64
+	Failed     uint64
+	Signaled   uint64
+	CoreDumped uint64
+
+	// IntervalReaped and Rate Number of children reaped in the last
+	// stats interval and the reap rate (per second) over that interval.
+	// Only updated with Config.StatsInterval set.
+	IntervalReaped uint64
+	Rate           float64
+
+	// Continued Number of children resumed by SIGCONT (WCONTINUED).
+	Continued uint64
+
+	// Stopped Number of children stopped by a signal (WUNTRACED).
+	Stopped uint64
+
+	// Adopted Number of orphans re-parented to us, as per
+	// Config.DetectAdoptions.
+	Adopted uint64
+
+	// Unreported Children reaped without being reported, as they were
+	// outside of Config.Cgroup (see Config.CgroupReportOnly).
+	Unreported uint64
+
+	// LastReap When the last child was reaped, zero if none was yet.
+	LastReap time.Time
+
+	// SweepReaped Children reaped by a periodic sweep with no SIGCHLD
+	// pending (see Config.SweepInterval), i.e. whose SIGCHLD got lost.
+	// Anything but zero is worth looking into.
+	SweepReaped uint64
+
+	// WaitErrors Sweeps cut short by wait4(2) failing with anything but
+	// ECHILD (no children).
+	WaitErrors uint64
+
+	// DroppedSignals SIGCHLDs that did not wake up the reap loop on
+	// their own, as there was a wakeup pending already or they were
+	// coalesced as per Config.MaxWakeupsPerSecond. This is harmless, a
+	// single sweep reaps all the dead children (see also
+	// Config.NotificationQueueSize and Config.OnDrop).
+	DroppedSignals uint64
+
+	// DroppedEvents Reap events not published to a subscriber (or the
+	// Config.StatusChannel) because its channel was full (counted once
+	// per subscriber).
+	DroppedEvents uint64
+
+	// DroppedCallbacks Reap events not handed to the callback because
+	// the callback queue was full.
+	DroppedCallbacks uint64
+
+	// SuppressedLogs Reap log lines dropped as per
+	// Config.MaxReapLogsPerSecond.
+	SuppressedLogs uint64
+
+	// DroppedSinkEvents Reap events not written to an event sink (see
+	// Config.EventSocket) as its queue was full or the reader gone.
+	DroppedSinkEvents uint64
+
+	// DroppedWebhooks Reaps not delivered to Config.WebhookURL, as the
+	// queue was full or the delivery failed for good.
+	DroppedWebhooks uint64
+
+	// Lifetimes and LifetimeSum Distribution of the lifetimes of the
+	// reaped children the reaper launched itself (see ReapEvent), the
+	// count in the i-th bucket is of children that lived no longer than
+	// LifetimeBuckets[i] (and longer than the bucket before). The last
+	// bucket counts the rest.
+	Lifetimes   [len(LifetimeBuckets) + 1]uint64
+	LifetimeSum time.Duration
+
+	// AdoptedLifetimes and AdoptedLifetimeSum The same for the children
+	// the reaper did not launch (orphans re-parented to us, mostly),
+	// only counted with Config.TrackLifetimes set. A long tail in here
+	// points at orphans that run away before they finally die.
+	AdoptedLifetimes   [len(LifetimeBuckets) + 1]uint64
+	AdoptedLifetimeSum time.Duration
+
+	// ExitCodes Number of reaped children by exit code ("0", "1" etc),
+	// or by signal ("signal:9") for the ones killed by a signal. Once
+	// maxExitCodes distinct codes are tracked, any further ones are
+	// lumped together under "other".
+	ExitCodes map[string]uint64
+}
+
+// Cap on the distinct codes tracked in Stats.ExitCodes.
+const maxExitCodes = 32
+
+// Bucket for codes beyond the cap in Stats.ExitCodes.
+const otherExitCodes = "other"
+
+// LifetimeBuckets Upper bounds of the Stats.Lifetimes buckets.
+var LifetimeBuckets = [...]time.Duration{
+	100 * time.Millisecond,
+	1 * time.Second,
+	10 * time.Second,
+	1 * time.Minute,
+	10 * time.Minute,
+	1 * time.Hour,
+}
+
+// Count a reaped child.
+func (stats *Stats) countReaped(outcome Outcome) {
+	stats.Reaped++
+
+	switch outcome {
+	case OutcomeExited:
+		stats.Exited++
+	case OutcomeFailed:
+		stats.Failed++
+	case OutcomeSignaled:
+		stats.Signaled++
+	case OutcomeCoreDumped:
+		stats.CoreDumped++
+	}
+
+} /*  End of method  Stats.countReaped.  */
+
+// Count the exit code (or signal) of a reaped child.
+func (stats *Stats) countExitCode(wstatus WaitStatus) {
+	code := strconv.Itoa(wstatus.ExitStatus())
+	if wstatus.Signaled() {
+		code = "signal:" + strconv.Itoa(int(wstatus.Signal()))
+	}
+
+	if stats.ExitCodes == nil {
+		stats.ExitCodes = make(map[string]uint64)
+	}
+
+	if _, ok := stats.ExitCodes[code]; !ok && len(stats.ExitCodes) >= maxExitCodes {
+		code = otherExitCodes
+	}
+
+	stats.ExitCodes[code]++
+
+} /*  End of method  Stats.countExitCode.  */
+
+// Copy of the stats that doesn't share the exit code map.
+func (stats Stats) clone() Stats {
+	if stats.ExitCodes != nil {
+		codes := make(map[string]uint64, len(stats.ExitCodes))
+		for code, count := range stats.ExitCodes {
+			codes[code] = count
+		}
+		stats.ExitCodes = codes
+	}
+
+	return stats
+
+} /*  End of method  Stats.clone.  */
+
+// Count the lifetime of a reaped child, if known, as per whether we
+// launched it or not.
+func (stats *Stats) countLifetime(lifetime time.Duration, launched bool) {
+	if lifetime <= 0 {
+		return
+	}
+
+	bucket := 0
+	for bucket < len(LifetimeBuckets) && lifetime > LifetimeBuckets[bucket] {
+		bucket++
+	}
+
+	if !launched {
+		stats.AdoptedLifetimes[bucket]++
+		stats.AdoptedLifetimeSum += lifetime
+		return
+	}
+
+	stats.Lifetimes[bucket]++
+	stats.LifetimeSum += lifetime
+
+} /*  End of method  Stats.countLifetime.  */
+
+// Snapshot of the stats, with the uptime worked out. Caller holds the
+// stats lock.
+func (r *Reaper) snapshotLocked() Stats {
+	stats := r.stats.clone()
+	if r.running {
+		stats.Uptime = time.Since(stats.Started)
+	}
+
+	return stats
+
+} /*  End of method  Reaper.snapshotLocked.  */
+
+// Update the stats under the stats lock.
+func (r *Reaper) countStat(update func(stats *Stats)) {
+	r.statsMu.Lock()
+	update(&r.stats)
+	r.statsMu.Unlock()
+
+} /*  End of method  Reaper.countStat.  */
+
+// The stats ticker's clock, a seam for the tests to tick it by hand:
+// the time it starts at and its ticks every interval, with the function
+// that stops them.
+var statsClock = func(interval time.Duration) (time.Time, <-chan time.Time, func()) {
+	ticker := time.NewTicker(interval)
+	return time.Now(), ticker.C, ticker.Stop
+}
+
+// Every stats interval, work out the reap rate over the interval and hand
+// a snapshot of the stats to the OnStats callback - until the context is
+// done. Runs in a goroutine of its own, off the reap loop.
+func (r *Reaper) statsTicker(ctx context.Context, interval time.Duration) {
+	last, ticks, stop := statsClock(interval)
+	defer stop()
+
+	lastReaped := r.Stats().Reaped
+
+	for {
+		var now time.Time
+
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticks:
+		}
+
+		r.statsMu.Lock()
+		reaped := r.stats.Reaped
+		r.stats.IntervalReaped = reaped - lastReaped
+		r.stats.Rate = float64(r.stats.IntervalReaped) / now.Sub(last).Seconds()
+		snapshot := r.snapshotLocked()
+		r.statsMu.Unlock()
+
+		last, lastReaped = now, reaped
+
+		if r.config.OnStats != nil {
+			r.config.OnStats(snapshot)
+		}
+	}
+
+} /*  End of method  Reaper.statsTicker.  */
+
+// Start the stats ticker if configured, returns a function that stops it
+// and waits for it to be gone.
+func (r *Reaper) startStats(ctx context.Context) func() {
+	if r.config.StatsInterval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		r.statsTicker(ctx, r.config.StatsInterval)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+
+} /*  End of method  Reaper.startStats.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Stats Get a snapshot of the reaper's stats.
+func (r *Reaper) Stats() Stats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	return r.snapshotLocked()
+
+} /*  End of [exported] method  Reaper.Stats.  */
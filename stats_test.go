@@ -0,0 +1,85 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOnStats(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ticks := make(chan time.Time)
+
+	saved := statsClock
+	statsClock = func(interval time.Duration) (time.Time, <-chan time.Time, func()) {
+		return start, ticks, func() {}
+	}
+	t.Cleanup(func() { statsClock = saved })
+
+	snapshots := make(chan Stats)
+	r := startTestReaper(t, Config{
+		StatsInterval: time.Hour,
+		OnStats:       func(stats Stats) { snapshots <- stats },
+	})
+
+	/*  The first interval, with nothing to reap.  */
+	ticks <- start.Add(time.Second)
+	if stats := <-snapshots; 0 != stats.IntervalReaped || 0 != stats.Rate {
+		t.Errorf("interval reaped %d at rate %v, expected none", stats.IntervalReaped, stats.Rate)
+	}
+
+	for idx := 0; idx < 4; idx++ {
+		spawn(t, "exit 0")
+	}
+	eventually(t, "4 reaps", func() bool { return 4 == r.Stats().Reaped })
+
+	ticks <- start.Add(3 * time.Second)
+	stats := <-snapshots
+	if 4 != stats.IntervalReaped || 2 != stats.Rate {
+		t.Errorf("interval reaped %d at rate %v, expected 4 at 2/s", stats.IntervalReaped, stats.Rate)
+	}
+
+	ticks <- start.Add(5 * time.Second)
+	stats = <-snapshots
+	if 0 != stats.IntervalReaped || 0 != stats.Rate || 4 != stats.Reaped {
+		t.Errorf("interval reaped %d at rate %v (%d in all), expected none of 4", stats.IntervalReaped, stats.Rate, stats.Reaped)
+	}
+
+} /*  End of function  TestOnStats.  */
+
+func TestCountExitCode(t *testing.T) {
+	var stats Stats
+
+	/*  Way more distinct exit codes than are tracked.  */
+	for code := 0; code < maxExitCodes+8; code++ {
+		stats.countExitCode(syscall.WaitStatus(code << 8))
+	}
+	stats.countExitCode(syscall.WaitStatus(0))
+	stats.countExitCode(syscall.WaitStatus(syscall.SIGKILL))
+
+	if maxExitCodes+1 != len(stats.ExitCodes) {
+		t.Errorf("tracked %d exit codes, expected %d and %q", len(stats.ExitCodes), maxExitCodes, otherExitCodes)
+	}
+	if 2 != stats.ExitCodes["0"] || 1 != stats.ExitCodes[strconv.Itoa(maxExitCodes-1)] {
+		t.Errorf("exit codes %v, expected the first ones counted as is", stats.ExitCodes)
+	}
+	if 9 != stats.ExitCodes[otherExitCodes] {
+		t.Errorf("%d exit codes counted as %q, expected 9", stats.ExitCodes[otherExitCodes], otherExitCodes)
+	}
+	if _, ok := stats.ExitCodes["signal:9"]; ok {
+		t.Errorf("signal tracked past the cap")
+	}
+
+	/*  Copies don't share the counts.  */
+	clone := stats.clone()
+	clone.countExitCode(syscall.WaitStatus(0))
+	if 2 != stats.ExitCodes["0"] {
+		t.Errorf("counting in a copy changed the original")
+	}
+
+} /*  End of function  TestCountExitCode.  */
@@ -0,0 +1,44 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+func TestStatusKeyvals(t *testing.T) {
+	for _, test := range []struct {
+		wstatus  syscall.WaitStatus
+		expected []interface{}
+	}{
+		{0, []interface{}{"outcome", "exited", "exit_code", 0, "raw_status", uint32(0)}},
+		{3 << 8, []interface{}{"outcome", "failed", "exit_code", 3, "raw_status", uint32(768)}},
+		{syscall.WaitStatus(syscall.SIGKILL), []interface{}{"outcome", "signaled", "signal", syscall.SIGKILL.String(), "core_dumped", false, "raw_status", uint32(syscall.SIGKILL)}},
+		{syscall.WaitStatus(syscall.SIGSEGV) | 0x80, []interface{}{"outcome", "core-dumped", "signal", syscall.SIGSEGV.String(), "core_dumped", true, "raw_status", uint32(syscall.SIGSEGV) | 0x80}},
+	} {
+		if keyvals := statusKeyvals(test.wstatus); !reflect.DeepEqual(test.expected, keyvals) {
+			t.Errorf("status %#x: %v, expected %v", uint32(test.wstatus), keyvals, test.expected)
+		}
+	}
+
+} /*  End of function  TestStatusKeyvals.  */
+
+func TestReapLogLine(t *testing.T) {
+	var logs syncBuffer
+	r := startTestReaper(t, Config{Debug: true, Logger: log.NewLogfmtLogger(&logs)})
+
+	pid := spawn(t, "exit 3")
+	eventually(t, "the reap", func() bool { return 1 == r.Stats().Reaped })
+
+	line := fmt.Sprintf("msg=\"clean up\" pid=%d outcome=failed exit_code=3 raw_status=768", pid)
+	eventually(t, "the reap to be logged", func() bool { return strings.Contains(logs.String(), line) })
+
+} /*  End of function  TestReapLogLine.  */
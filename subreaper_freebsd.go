@@ -0,0 +1,30 @@
+//go:build freebsd && (amd64 || arm64 || riscv64)
+// +build freebsd
+// +build amd64 arm64 riscv64
+
+package reaper
+
+//  Prefer #include style directives.
+import "syscall"
+
+const (
+	/*  procctl(2) idtype and command, see <sys/procctl.h>.  */
+	pPIDFreeBSD     = 0
+	procReapAcquire = 2
+)
+
+// Become the reaper of our descendants (procctl PROC_REAP_ACQUIRE), the
+// FreeBSD take on a linux child subreaper: orphaned descendants get
+// re-parented to us rather than to init (or the jail's reaper). Only
+// wired up for 64-bit platforms, where the 64-bit id_t fits a register.
+func setSubreaper() error {
+	pid := syscall.Getpid()
+
+	_, _, errno := syscall.Syscall6(syscall.SYS_PROCCTL, pPIDFreeBSD, uintptr(pid), procReapAcquire, 0, 0, 0)
+	if 0 != errno {
+		return errno
+	}
+
+	return nil
+
+} /*  End of function  setSubreaper.  */
@@ -0,0 +1,22 @@
+//go:build linux
+// +build linux
+
+package reaper
+
+//  Prefer #include style directives.
+import "syscall"
+
+// prctl(2) option to make us the reaper of our orphaned descendants.
+const prSetChildSubreaper = 36
+
+// Become a child subreaper: orphaned descendants get re-parented to us
+// rather than to pid 1 (or the closest subreaper up the tree).
+func setSubreaper() error {
+	_, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetChildSubreaper, 1, 0)
+	if 0 != errno {
+		return errno
+	}
+
+	return nil
+
+} /*  End of function  setSubreaper.  */
@@ -0,0 +1,11 @@
+//go:build !linux && !(freebsd && (amd64 || arm64 || riscv64))
+// +build !linux
+// +build !freebsd !amd64,!arm64,!riscv64
+
+package reaper
+
+// Child subreapers are a linux (and 64-bit FreeBSD) thing.
+func setSubreaper() error {
+	return ErrUnsupportedPlatform
+
+} /*  End of function  setSubreaper.  */
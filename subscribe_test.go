@@ -0,0 +1,80 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"testing"
+)
+
+// Drain a subscription (closed once the reaper stops) into a slice.
+func drain(events <-chan ReapEvent) []ReapEvent {
+	var drained []ReapEvent
+	for event := range events {
+		drained = append(drained, event)
+	}
+
+	return drained
+
+} /*  End of function  drain.  */
+
+func TestSubscribeOrdering(t *testing.T) {
+	const children = 50
+
+	r := startTestReaper(t, Config{})
+
+	first, _ := r.Subscribe(children)
+	second, _ := r.Subscribe(children)
+	slow, _ := r.Subscribe(2)
+
+	/*  Dying all at once, more or less, for the reaps to pile up.  */
+	for idx := 0; idx < children; idx++ {
+		spawn(t, "sleep 0.1")
+	}
+	eventually(t, "the reaps", func() bool { return children == r.Stats().Reaped })
+
+	r.Stop()
+
+	received := [][]ReapEvent{drain(first), drain(second), drain(slow)}
+	for idx, events := range received {
+		for pos := 1; pos < len(events); pos++ {
+			if events[pos].Seq <= events[pos-1].Seq {
+				t.Errorf("subscriber %d got seq %d after %d, expected reap order", idx, events[pos].Seq, events[pos-1].Seq)
+			}
+		}
+	}
+
+	/*  The slow one missed out, the others didn't.  */
+	if children != len(received[0]) || children != len(received[1]) {
+		t.Errorf("subscribers got %d and %d events, expected %d each", len(received[0]), len(received[1]), children)
+	}
+	if 2 != len(received[2]) || children-2 != r.Stats().DroppedEvents {
+		t.Errorf("slow subscriber got %d events with %d dropped, expected 2 and %d", len(received[2]), r.Stats().DroppedEvents, children-2)
+	}
+	for pos := range received[0] {
+		if received[0][pos].Seq != received[1][pos].Seq {
+			t.Fatalf("subscribers got seq %d and %d at %d, expected the same order", received[0][pos].Seq, received[1][pos].Seq, pos)
+		}
+	}
+
+} /*  End of function  TestSubscribeOrdering.  */
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	r := startTestReaper(t, Config{})
+
+	events, unsubscribe := r.Subscribe(1)
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Fatalf("got an event once unsubscribed")
+	}
+
+	r.Stop()
+	events, _ = r.Subscribe(1)
+	if _, ok := <-events; ok {
+		t.Fatalf("got an event subscribing to a stopped reaper")
+	}
+
+} /*  End of function  TestSubscribeUnsubscribe.  */
@@ -0,0 +1,330 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// RestartPolicy Controls whether a supervised child is relaunched after it
+// has been reaped.
+type RestartPolicy int
+
+const (
+	// RestartNever Never relaunch the child, supervise it just once.
+	RestartNever RestartPolicy = iota
+
+	// RestartOnFailure Relaunch the child only if it did not exit cleanly
+	// (non-zero exit code or killed by a signal).
+	RestartOnFailure
+
+	// RestartAlways Always relaunch the child, no matter how it exited.
+	RestartAlways
+)
+
+// Backoff Delay between successive restarts of a supervised child. The
+// delay starts at Initial and is multiplied by Factor on every restart,
+// capped at Max. Once a child stays up for at least Max, the delay is
+// reset back to Initial.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// ChildSpec Describes a child process for the reaper to launch and
+// supervise. Args defaults to []string{Path} and Env to the environment
+// of the current process. The child shares our stdin, stdout and stderr.
+//
+// ExpectedExitCodes are the non-zero exit codes the child is expected to
+// exit with, any other exit is marked as Unexpected (see ReapEvent).
+// MaxRestarts caps the restarts in a row (zero means no cap), a child
+// that stays up for at least Backoff.Max starts over with a clean slate.
+// GracePeriod, if set, is how long the child gets between the SIGTERM
+// and the SIGKILL when the reaper shuts its children down - in place of
+// Config.GracePeriod, which still decides whether they are shut down at
+// all. For services that take their time to stop (or that ought not to).
+type ChildSpec struct {
+	Path    string
+	Args    []string
+	Env     []string
+	Dir     string
+	Restart RestartPolicy
+	Backoff Backoff
+
+	ExpectedExitCodes []int
+	MaxRestarts       int
+	GracePeriod       time.Duration
+}
+
+const (
+	defaultBackoffInitial = 1 * time.Second
+	defaultBackoffMax     = 30 * time.Second
+	defaultBackoffFactor  = 2.0
+)
+
+// Check if a child with the given exit status needs to be relaunched.
+func (p RestartPolicy) restart(wstatus WaitStatus) bool {
+	switch p {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return !wstatus.Exited() || 0 != wstatus.ExitStatus()
+	}
+
+	return false
+
+} /*  End of method  RestartPolicy.restart.  */
+
+// Fill in the defaults for any unset backoff parameters.
+func (b Backoff) withDefaults() Backoff {
+	if b.Initial <= 0 {
+		b.Initial = defaultBackoffInitial
+	}
+	if b.Max < b.Initial {
+		b.Max = defaultBackoffMax
+		if b.Max < b.Initial {
+			b.Max = b.Initial
+		}
+	}
+	if b.Factor < 1 {
+		b.Factor = defaultBackoffFactor
+	}
+
+	return b
+
+} /*  End of method  Backoff.withDefaults.  */
+
+// Compute the delay to use after the given one.
+func (b Backoff) next(delay time.Duration) time.Duration {
+	delay = time.Duration(float64(delay) * b.Factor)
+	if delay > b.Max {
+		return b.Max
+	}
+
+	return delay
+
+} /*  End of method  Backoff.next.  */
+
+// Fork off the child described by spec. The child's pid is registered
+// with the reaper while still holding the lock, so that even a child
+// which dies straight away can't be reaped before we are waiting on it.
+func (r *Reaper) launch(spec ChildSpec) (int, <-chan ReapEvent, error) {
+	args := spec.Args
+	if len(args) == 0 {
+		args = []string{spec.Path}
+	}
+
+	env := spec.Env
+	if env == nil {
+		env = os.Environ()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pid, err := forkExec(spec.Path, args, spec.Dir, env, r.config.ParentDeathSignal)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	exited := make(chan ReapEvent, 1)
+	r.waiters[pid] = exited
+	r.launched[pid] = time.Now()
+	if len(spec.ExpectedExitCodes) > 0 {
+		r.expected[pid] = spec.ExpectedExitCodes
+	}
+	if spec.GracePeriod > 0 {
+		r.graces[pid] = spec.GracePeriod
+	}
+	if r.config.UsePidfd {
+		r.watchPidfdLocked(pid)
+	}
+
+	return pid, exited, nil
+
+} /*  End of method  Reaper.launch.  */
+
+// Stop a supervised child once supervision is over: send it a SIGTERM,
+// followed by a SIGKILL once its grace period is up (if it has one), and
+// wait for the reaper to reap it.
+func (r *Reaper) stopSupervised(spec ChildSpec, pid int, exited <-chan ReapEvent) error {
+	level.Info(r.config.Logger).Log("msg", "stopping supervised child", "path", spec.Path, "pid", pid, "grace_period", spec.GracePeriod)
+
+	/*  A child gone since is just about to be reaped - no harm done.  */
+	r.Signal(pid, sigTERM)
+
+	var deadline <-chan time.Time
+	if spec.GracePeriod > 0 {
+		timer := time.NewTimer(spec.GracePeriod)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	killed := false
+	for {
+		select {
+		case <-exited:
+			return nil
+		case <-r.done:
+			return ErrNotRunning
+		case <-deadline:
+		}
+
+		if killed {
+			return ErrNotReaped
+		}
+
+		level.Info(r.config.Logger).Log("msg", "killing supervised child", "path", spec.Path, "pid", pid, "grace_period", spec.GracePeriod)
+		r.Signal(pid, sigKILL)
+
+		timer := time.NewTimer(killReapTimeout)
+		defer timer.Stop()
+		deadline, killed = timer.C, true
+	}
+
+} /*  End of method  Reaper.stopSupervised.  */
+
+// Watch over a supervised child, relaunching it as per its restart policy
+// until it is done for good, the context is done (the child is then
+// stopped) or the reaper is. Returns why supervision is over.
+func (r *Reaper) supervise(ctx context.Context, spec ChildSpec, pid int, exited <-chan ReapEvent) error {
+	logger := r.config.Logger
+	backoff := spec.Backoff.withDefaults()
+	delay := backoff.Initial
+	restarts := 0
+
+	for {
+		var wstatus WaitStatus
+		started := time.Now()
+
+		select {
+		case <-r.done:
+			return ErrNotRunning
+		case <-ctx.Done():
+			if err := r.stopSupervised(spec, pid, exited); err != nil {
+				return err
+			}
+			return ctx.Err()
+		case event := <-exited:
+			wstatus = event.Status
+		}
+
+		if !spec.Restart.restart(wstatus) {
+			keyvals := append([]interface{}{"msg", "supervised child done", "path", spec.Path, "pid", pid}, statusKeyvals(wstatus)...)
+			level.Debug(logger).Log(keyvals...)
+			return nil
+		}
+
+		if time.Since(started) >= backoff.Max {
+			delay, restarts = backoff.Initial, 0
+		}
+
+		for {
+			if spec.MaxRestarts > 0 && restarts >= spec.MaxRestarts {
+				level.Error(logger).Log("msg", "supervised child restarted too often, giving up", "path", spec.Path, "restarts", restarts)
+				return ErrTooManyRestarts
+			}
+
+			keyvals := append([]interface{}{"msg", "restarting supervised child", "path", spec.Path, "pid", pid}, statusKeyvals(wstatus)...)
+			level.Info(logger).Log(append(keyvals, "delay", delay)...)
+
+			select {
+			case <-r.done:
+				return ErrNotRunning
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			delay = backoff.next(delay)
+			restarts++
+
+			var err error
+			pid, exited, err = r.launch(spec)
+			if err == nil {
+				break
+			}
+
+			/*  Couldn't even start it - back off some more.  */
+			level.Error(logger).Log("msg", "failed to restart supervised child", "path", spec.Path, "err", err)
+		}
+	}
+
+} /*  End of method  Reaper.supervise.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Restart Check if a child that exited with the given status is to be
+// relaunched as per the policy.
+func (p RestartPolicy) Restart(wstatus WaitStatus) bool {
+	return p.restart(wstatus)
+
+} /*  End of [exported] method  RestartPolicy.Restart.  */
+
+// Next The delay to back off for after the given one, with the defaults
+// filled in for any unset parameters: Initial for the first (a delay of
+// zero), then Factor times the previous one, capped at Max.
+func (b Backoff) Next(delay time.Duration) time.Duration {
+	b = b.withDefaults()
+	if delay <= 0 {
+		return b.Initial
+	}
+
+	return b.next(delay)
+
+} /*  End of [exported] method  Backoff.Next.  */
+
+// Reset How long a child has to stay up for the backoff to be reset,
+// i.e. Max with the defaults filled in.
+func (b Backoff) Reset() time.Duration {
+	return b.withDefaults().Max
+
+} /*  End of [exported] method  Backoff.Reset.  */
+
+// Supervise Launch the child described by spec and keep relaunching it as
+// per its restart policy whenever the reaper reaps it. Between restarts
+// the reaper backs off as per spec.Backoff. Supervision stops when the
+// reaper's Run returns (context cancelled), any still running child is
+// left alone.
+//
+// Only the first launch error is returned, later restart failures are
+// logged and retried with backoff.
+func (r *Reaper) Supervise(spec ChildSpec) error {
+	_, err := r.SuperviseContext(context.Background(), spec)
+	return err
+
+} /*  End of [exported] method  Reaper.Supervise.  */
+
+// SuperviseContext Like Supervise, but supervision also stops once ctx is
+// done - and then so does the child: it is sent a SIGTERM, followed by a
+// SIGKILL once spec.GracePeriod is up if that is set, and waited on until
+// the reaper reaps it. The returned channel gets the outcome once the
+// supervision is over: nil if the child is done for good as per its
+// restart policy, ErrTooManyRestarts if it exited yet again after
+// spec.MaxRestarts restarts in a row, ErrNotRunning if the reaper
+// stopped first or else the context's error, once the child is gone.
+func (r *Reaper) SuperviseContext(ctx context.Context, spec ChildSpec) (<-chan error, error) {
+	pid, exited, err := r.launch(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := make(chan error, 1)
+	go func() {
+		defer close(outcome)
+		outcome <- r.supervise(ctx, spec, pid, exited)
+	}()
+
+	return outcome, nil
+
+} /*  End of [exported] method  Reaper.SuperviseContext.  */
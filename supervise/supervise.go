@@ -0,0 +1,311 @@
+// Package supervise A small process supervisor built on the grim reaper:
+// declare the child commands to run, each with a restart policy, backoff
+// and a cap on the restarts, and the supervisor keeps them going. Each
+// child is supervised by the reaper itself (see Reaper.SuperviseContext),
+// what this package adds is running a set of them by name: children can
+// depend on one another, they are then started in order and stopped in
+// the reverse order.
+//
+//	r := reaper.New(reaper.Config{Pid: -1})
+//	go r.Run(ctx)
+//
+//	s := &supervise.Supervisor{
+//		Reaper: r,
+//		Children: []supervise.Child{
+//			{Name: "db", Path: "/usr/bin/db", Restart: reaper.RestartAlways},
+//			{Name: "web", Path: "/usr/bin/web", Restart: reaper.RestartAlways, After: []string{"db"}},
+//			{Name: "cron", Path: "/usr/sbin/crond", Restart: reaper.RestartOnFailure, MaxRestarts: 5},
+//		},
+//	}
+//	err := s.Run(ctx)
+package supervise
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+// Child A child command to supervise. Args defaults to []string{Path}
+// and Env to the environment of the current process, the child shares
+// our stdin, stdout and stderr. Name (default the base name of Path)
+// identifies the child in the logs.
+//
+// After an exit Restart decides whether the child is relaunched, with a
+// delay as per Backoff in between. MaxRestarts caps the number of
+// restarts in a row (zero means no cap), a child that stays up for at
+// least Backoff.Max starts over with a clean slate.
+//
+// After lists the names of the children this one depends on: they are
+// started before it, and stopped only once it is gone. Restarts don't
+// ripple through, a dependency that is restarted leaves its dependents
+// be.
+//
+// GracePeriod is how long the child gets to exit once it is sent a
+// SIGTERM to stop it, before it is SIGKILLed (see Reaper.Terminate).
+// Zero means no limit, the child is waited on for as long as it takes.
+type Child struct {
+	Name    string
+	Path    string
+	Args    []string
+	Env     []string
+	Dir     string
+	Restart reaper.RestartPolicy
+	Backoff reaper.Backoff
+	After   []string
+
+	MaxRestarts int
+	GracePeriod time.Duration
+}
+
+// proc A child as run by the supervisor: the cancellation of its
+// supervision and the outcome of it, which is in once ended is closed.
+type proc struct {
+	child   Child
+	cancel  context.CancelFunc
+	outcome <-chan error
+	ended   chan struct{}
+	err     error
+}
+
+// Supervisor Runs and restarts its Children, with the Reaper (which has
+// to be running) doing all the waiting - a lightweight s6 style init for
+// containers. Logs go to Logger, if any.
+type Supervisor struct {
+	Reaper   *reaper.Reaper
+	Children []Child
+	Logger   reaper.Logger
+}
+
+// ErrTooManyRestarts A child was restarted MaxRestarts times in a row and
+// exited yet again.
+var ErrTooManyRestarts = reaper.ErrTooManyRestarts
+
+// ErrBadDependencies The children's dependencies (see Child.After) can't
+// be satisfied: a duplicate or unknown name, or a cycle.
+var ErrBadDependencies = errors.New("supervise: bad dependencies")
+
+// The name a child goes by.
+func (c Child) name() string {
+	if "" != c.Name {
+		return c.Name
+	}
+
+	return filepath.Base(c.Path)
+
+} /*  End of method  Child.name.  */
+
+// The spec the reaper supervises the child as.
+func (c Child) spec() reaper.ChildSpec {
+	return reaper.ChildSpec{
+		Path:    c.Path,
+		Args:    c.Args,
+		Env:     c.Env,
+		Dir:     c.Dir,
+		Restart: c.Restart,
+		Backoff: c.Backoff,
+
+		MaxRestarts: c.MaxRestarts,
+		GracePeriod: c.GracePeriod,
+	}
+
+} /*  End of method  Child.spec.  */
+
+// The logger to use, a no-op one if none is set.
+func (s *Supervisor) logger() reaper.Logger {
+	if nil == s.Logger {
+		return log.NewNopLogger()
+	}
+
+	return s.Logger
+
+} /*  End of method  Supervisor.logger.  */
+
+// Order the children such that every one of them comes after the ones
+// it depends on. Otherwise the children stay in the order given.
+func order(children []Child) ([]Child, error) {
+	index := make(map[string]int, len(children))
+	for idx, child := range children {
+		if _, ok := index[child.name()]; ok {
+			return nil, fmt.Errorf("%w: duplicate name %s", ErrBadDependencies, child.name())
+		}
+		index[child.name()] = idx
+	}
+
+	for _, child := range children {
+		for _, dep := range child.After {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on unknown %s", ErrBadDependencies, child.name(), dep)
+			}
+		}
+	}
+
+	ordered := make([]Child, 0, len(children))
+	placed := make([]bool, len(children))
+	for len(ordered) < len(children) {
+		progress := false
+		for idx, child := range children {
+			if placed[idx] {
+				continue
+			}
+
+			ready := true
+			for _, dep := range child.After {
+				ready = ready && placed[index[dep]]
+			}
+			if ready {
+				ordered = append(ordered, child)
+				placed[idx], progress = true, true
+			}
+		}
+
+		if !progress {
+			return nil, fmt.Errorf("%w: dependency cycle", ErrBadDependencies)
+		}
+	}
+
+	return ordered, nil
+
+} /*  End of function  order.  */
+
+// Have the reaper start and supervise a child, until stopped (see stop).
+func (s *Supervisor) start(child Child) (*proc, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	outcome, err := s.Reaper.SuperviseContext(ctx, child.spec())
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	p := &proc{child: child, cancel: cancel, outcome: outcome, ended: make(chan struct{})}
+	go func() {
+		defer close(p.ended)
+		p.err = <-outcome
+	}()
+
+	return p, nil
+
+} /*  End of method  Supervisor.start.  */
+
+// Stop a child's supervision and, if it is still running, the child
+// (SIGTERM, then SIGKILL once its grace period is up) - waiting for the
+// reaper to confirm it is gone.
+func (s *Supervisor) stop(p *proc) error {
+	select {
+	case <-p.ended:
+		return nil
+	default:
+	}
+
+	level.Info(s.logger()).Log("msg", "stopping supervised child", "child", p.child.name(), "grace_period", p.child.GracePeriod)
+	p.cancel()
+	<-p.ended
+
+	if errors.Is(p.err, context.Canceled) {
+		return nil
+	}
+
+	return p.err
+
+} /*  End of method  Supervisor.stop.  */
+
+// Stop the children still running, dependents before their dependencies
+// i.e. in the reverse start order, each one confirmed gone by the reaper
+// before the next one is stopped. Returns the first error.
+func (s *Supervisor) stopAll(procs []*proc) error {
+	var first error
+	for idx := len(procs) - 1; idx >= 0; idx-- {
+		if err := s.stop(procs[idx]); err != nil && nil == first {
+			first = err
+		}
+	}
+
+	return first
+
+} /*  End of method  Supervisor.stopAll.  */
+
+// The first error of the children done already, in start order.
+func firstErr(procs []*proc) error {
+	for _, p := range procs {
+		select {
+		case <-p.ended:
+			if p.err != nil {
+				return p.err
+			}
+		default:
+		}
+	}
+
+	return nil
+
+} /*  End of function  firstErr.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Run Start all the children, each one after the ones it depends on, and
+// supervise them until the context is done. Then the ones still running
+// are stopped (SIGTERM, then SIGKILL once a child's GracePeriod is up)
+// in the reverse order, waiting for the reaper to reap each one before
+// moving on to the next - so a child never outlives what it depends on.
+// Also returns once every child is done for good - as per its restart
+// policy, or given up on after MaxRestarts.
+//
+// If the dependencies can't be satisfied (ErrBadDependencies) nothing is
+// started. If a child fails to start, the ones already started are
+// stopped again and the error is returned. Otherwise the result is the
+// first error of any child (ErrTooManyRestarts, or reaper.ErrNotRunning
+// once the reaper stopped) or, if there is none, the context's error.
+func (s *Supervisor) Run(ctx context.Context) error {
+	children, err := order(s.Children)
+	if err != nil {
+		return err
+	}
+
+	procs := make([]*proc, 0, len(children))
+	for _, child := range children {
+		p, err := s.start(child)
+		if err != nil {
+			s.stopAll(procs)
+			return fmt.Errorf("supervise: starting %s: %w", child.name(), err)
+		}
+
+		level.Debug(s.logger()).Log("msg", "started supervised child", "child", child.name())
+		procs = append(procs, p)
+	}
+
+	/*  Until every child is done for good, or the context is.  */
+	for _, p := range procs {
+		select {
+		case <-ctx.Done():
+		case <-p.ended:
+			continue
+		}
+		break
+	}
+
+	err = firstErr(procs)
+	if serr := s.stopAll(procs); serr != nil && nil == err {
+		err = serr
+	}
+
+	if nil == err {
+		err = ctx.Err()
+	}
+
+	return err
+
+} /*  End of [exported] method  Supervisor.Run.  */
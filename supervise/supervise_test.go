@@ -0,0 +1,125 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package supervise
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	reaper "github.com/kakkoyun/go-reaper"
+)
+
+// Start a reaper of any of the test binary's children, stopped once the
+// test is done.
+func startReaper(t *testing.T) *reaper.Reaper {
+	t.Helper()
+
+	r := reaper.New(reaper.Config{Pid: -1, DisablePid1Check: true, AllowHostReaping: true, Logger: log.NewNopLogger()})
+	go r.Run(context.Background())
+
+	/*  Healthy once the reap loop is up.  */
+	for deadline := time.Now().Add(5 * time.Second); nil != r.Healthy(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("reaper failed to start: %v", r.Healthy())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Cleanup(r.Stop)
+	return r
+
+} /*  End of function  startReaper.  */
+
+func TestOrder(t *testing.T) {
+	ordered, err := order([]Child{
+		{Name: "web", After: []string{"db"}},
+		{Name: "cron"},
+		{Name: "db"},
+	})
+	if err != nil {
+		t.Fatalf("order failed: %v", err)
+	}
+
+	names := ""
+	for _, child := range ordered {
+		names += child.name() + " "
+	}
+	if "cron db web " != names {
+		t.Errorf("ordered %q, expected \"cron db web \"", names)
+	}
+
+	for _, children := range [][]Child{
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", After: []string{"b"}}},
+		{{Name: "a", After: []string{"b"}}, {Name: "b", After: []string{"a"}}},
+	} {
+		if _, err := order(children); !errors.Is(err, ErrBadDependencies) {
+			t.Errorf("order of %+v: %v, expected ErrBadDependencies", children, err)
+		}
+	}
+
+} /*  End of function  TestOrder.  */
+
+func TestRunTooManyRestarts(t *testing.T) {
+	s := &Supervisor{
+		Reaper: startReaper(t),
+		Children: []Child{{
+			Path:        "/bin/sh",
+			Args:        []string{"sh", "-c", "exit 3"},
+			Restart:     reaper.RestartOnFailure,
+			Backoff:     reaper.Backoff{Initial: time.Millisecond, Max: time.Second},
+			MaxRestarts: 2,
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.Run(ctx); !errors.Is(err, ErrTooManyRestarts) {
+		t.Fatalf("run: %v, expected ErrTooManyRestarts", err)
+	}
+
+} /*  End of function  TestRunTooManyRestarts.  */
+
+func TestRunStopsInReverseOrder(t *testing.T) {
+	r := startReaper(t)
+	events, unsubscribe := r.Subscribe(8)
+	defer unsubscribe()
+
+	s := &Supervisor{
+		Reaper: r,
+		Children: []Child{
+			{Name: "web", Path: "/bin/sleep", Args: []string{"sleep", "30"}, Restart: reaper.RestartAlways, After: []string{"db"}},
+			{Name: "db", Path: "/bin/sleep", Args: []string{"sleep", "30"}, Restart: reaper.RestartAlways, GracePeriod: time.Second},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if err := s.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("run: %v, expected the context's error", err)
+	}
+
+	var reaped []int
+	for len(reaped) < 2 {
+		select {
+		case event := <-events:
+			if reaper.EventReaped == event.Type {
+				reaped = append(reaped, event.Pid)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("children not reaped, got %v", reaped)
+		}
+	}
+
+	if reaped[0] < reaped[1] {
+		t.Errorf("reaped %v, expected web (started last) to be stopped first", reaped)
+	}
+
+} /*  End of function  TestRunStopsInReverseOrder.  */
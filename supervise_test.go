@@ -0,0 +1,63 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestStopSupervised(t *testing.T) {
+	if _, err := os.Stat("/proc/self/comm"); err != nil {
+		t.Skipf("no /proc to tell when the trap is set: %v", err)
+	}
+
+	const grace = 200 * time.Millisecond
+	r := startTestReaper(t, Config{GracePeriod: time.Hour})
+
+	/*  The child's own grace period, not the reaper's.  */
+	spec := ChildSpec{Path: "/bin/sh", Args: []string{"sh", "-c", stubbornScript}, GracePeriod: grace}
+	pid, exited, err := r.launch(spec)
+	if err != nil {
+		t.Fatalf("failed to launch child: %v", err)
+	}
+	awaitTrap(t, pid)
+
+	/*  Wait on exited ourselves, to see how it went.  */
+	watched := make(chan ReapEvent, 1)
+	reaped := make(chan ReapEvent)
+	go func() {
+		event := <-exited
+		watched <- event
+		close(reaped)
+	}()
+
+	started := time.Now()
+	if err := r.stopSupervised(spec, pid, reaped); err != nil {
+		t.Errorf("stopping the child failed: %v", err)
+	}
+	if took := time.Since(started); took < grace || took > grace+time.Second {
+		t.Errorf("child stopped after %v, expected %v", took, grace)
+	}
+	if event := <-watched; syscall.SIGKILL != event.Status.Signal() || !event.SelfSignaled {
+		t.Errorf("child reaped with %#x, self signaled %v, expected a SIGKILL of ours", uint32(event.Status), event.SelfSignaled)
+	}
+
+} /*  End of function  TestStopSupervised.  */
+
+func TestStopSupervisedNotReaped(t *testing.T) {
+	fastKillReaps(t, 100*time.Millisecond)
+	r := startTestReaper(t, Config{})
+
+	/*  Reaped all right, but nobody tells us.  */
+	spec := ChildSpec{Path: "/bin/sh", GracePeriod: 100 * time.Millisecond}
+	if err := r.stopSupervised(spec, spawn(t, "exec sleep 10"), make(chan ReapEvent)); !errors.Is(err, ErrNotReaped) {
+		t.Errorf("stopping a child that never gets reported reaped failed with %v, expected ErrNotReaped", err)
+	}
+
+} /*  End of function  TestStopSupervisedNotReaped.  */
@@ -0,0 +1,108 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// What the fake wait4 is to return, call by call.
+type waitResult struct {
+	pid     int
+	wstatus syscall.WaitStatus
+	err     error
+}
+
+// Have wait4 return the given results, and ECHILD once they run out.
+// Returns the number of calls made so far.
+func fakeWait4(t *testing.T, results ...waitResult) func() int {
+	saved := wait4
+	t.Cleanup(func() { wait4 = saved })
+
+	calls := 0
+	wait4 = func(pid int, wstatus *syscall.WaitStatus, options int, rusage *syscall.Rusage) (int, error) {
+		calls++
+		if 0 == len(results) {
+			return -1, syscall.ECHILD
+		}
+
+		result := results[0]
+		results = results[1:]
+		*wstatus = result.wstatus
+		return result.pid, result.err
+	}
+
+	return func() int { return calls }
+
+} /*  End of function  fakeWait4.  */
+
+func TestSweepNoChildReady(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		results []waitResult
+		reaped  int
+		calls   int
+		errors  uint64
+	}{
+		{"none ready", []waitResult{{pid: 0}, {pid: 42}}, 0, 1, 0},
+		{"one, then none ready", []waitResult{{pid: 42, wstatus: 3 << 8}, {pid: 0}, {pid: 43}}, 1, 2, 0},
+		{"no children", []waitResult{{pid: -1, err: syscall.ECHILD}, {pid: 42}}, 0, 1, 0},
+		{"interrupted", []waitResult{{pid: -1, err: syscall.EINTR}, {pid: 42}, {pid: 0}}, 1, 3, 0},
+		{"failed", []waitResult{{pid: -1, err: syscall.EINVAL}, {pid: 42}}, 0, 1, 1},
+	} {
+		calls := fakeWait4(t, test.results...)
+		r := New(Config{Pid: -1, Logger: log.NewNopLogger()})
+
+		reaped := r.sweepWith(context.Background(), context.Background(), nil, wNOHANG)
+		if test.reaped != reaped || test.calls != calls() {
+			t.Errorf("%s: reaped %d in %d waits, expected %d in %d", test.name, reaped, calls(), test.reaped, test.calls)
+		}
+
+		stats := r.Stats()
+		if uint64(test.reaped) != stats.Reaped || test.errors != stats.WaitErrors {
+			t.Errorf("%s: counted %d reaps and %d wait errors, expected %d and %d", test.name, stats.Reaped, stats.WaitErrors, test.reaped, test.errors)
+		}
+	}
+
+} /*  End of function  TestSweepNoChildReady.  */
+
+func TestSweepCancelled(t *testing.T) {
+	/*  Children that never become ready, a sweep waits on forever.  */
+	var waits atomic.Int64
+	saved := wait4
+	t.Cleanup(func() { wait4 = saved })
+	wait4 = func(pid int, wstatus *syscall.WaitStatus, options int, rusage *syscall.Rusage) (int, error) {
+		waits.Add(1)
+		return 0, nil
+	}
+
+	r := startTestReaper(t, Config{})
+	syscall.Kill(os.Getpid(), syscall.SIGCHLD)
+	eventually(t, "the sweep", func() bool { return waits.Load() > 0 })
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		r.Stop()
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("stop blocked on a sweep")
+	}
+
+	if stats := r.Stats(); 0 != stats.Reaped || 0 != stats.WaitErrors {
+		t.Errorf("counted %d reaps and %d wait errors, expected none", stats.Reaped, stats.WaitErrors)
+	}
+
+} /*  End of function  TestSweepCancelled.  */
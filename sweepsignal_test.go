@@ -0,0 +1,39 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+func TestSweepOnSignals(t *testing.T) {
+	/*  No children to be had, just counting the sweeps.  */
+	var sweeps atomic.Int64
+	saved := wait4
+	t.Cleanup(func() { wait4 = saved })
+	wait4 = func(pid int, wstatus *syscall.WaitStatus, options int, rusage *syscall.Rusage) (int, error) {
+		sweeps.Add(1)
+		return -1, syscall.ECHILD
+	}
+
+	r := startTestReaper(t, Config{SweepOnSignals: []os.Signal{syscall.SIGUSR1}})
+
+	for _, sig := range []syscall.Signal{syscall.SIGUSR1, syscall.SIGCHLD, syscall.SIGUSR1} {
+		before := sweeps.Load()
+		if err := syscall.Kill(os.Getpid(), sig); err != nil {
+			t.Fatalf("failed to send %v: %v", sig, err)
+		}
+		eventually(t, "a sweep on "+sig.String(), func() bool { return sweeps.Load() > before })
+	}
+
+	r.Stop()
+	if reason := r.ShutdownReason(); ReasonContext != reason {
+		t.Errorf("shutdown reason %v, expected %v", reason, ReasonContext)
+	}
+
+} /*  End of function  TestSweepOnSignals.  */
@@ -0,0 +1,23 @@
+//go:build !plan9
+// +build !plan9
+
+package reaper
+
+//  Prefer #include style directives.
+import "syscall"
+
+// WaitStatus The status of a child as reported by wait4(2), just the
+// syscall package's.
+type WaitStatus = syscall.WaitStatus
+
+// Signal A signal to send to a child, just the syscall package's.
+type Signal = syscall.Signal
+
+// The resource usage of a child as reported by wait4(2).
+type sysUsage = syscall.Rusage
+
+const (
+	sigTERM = syscall.SIGTERM
+	sigKILL = syscall.SIGKILL
+	eCHILD  = syscall.ECHILD
+)
@@ -0,0 +1,42 @@
+//go:build plan9
+// +build plan9
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"strconv"
+	"syscall"
+)
+
+/*
+ *  plan9 has notes and wait messages rather than signals and wait
+ *  statuses. Just enough of the two for the package's API to compile,
+ *  there is nothing to reap here (see ErrUnsupportedPlatform).
+ */
+
+// WaitStatus The status of a child, never reported here.
+type WaitStatus uint32
+
+// Signal A signal to send to a child, there are none here.
+type Signal int
+
+type sysUsage struct{}
+
+const (
+	sigTERM = Signal(15)
+	sigKILL = Signal(9)
+)
+
+var eCHILD error = syscall.ErrorString("no child processes")
+
+func (w WaitStatus) Exited() bool       { return true }
+func (w WaitStatus) ExitStatus() int    { return int(w) }
+func (w WaitStatus) Signaled() bool     { return false }
+func (w WaitStatus) Signal() Signal     { return 0 }
+func (w WaitStatus) CoreDump() bool     { return false }
+func (w WaitStatus) Stopped() bool      { return false }
+func (w WaitStatus) StopSignal() Signal { return 0 }
+func (w WaitStatus) Continued() bool    { return false }
+func (s Signal) String() string         { return "signal " + strconv.Itoa(int(s)) }
+func (s Signal) Signal()                {}
@@ -0,0 +1,37 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+)
+
+func TestRuntimeTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("can't trace: %v", err)
+	}
+
+	r := startTestReaper(t, Config{EnableRuntimeTrace: true})
+	spawn(t, "exit 0")
+	eventually(t, "the reap", func() bool { return 1 == r.Stats().Reaped })
+	r.Stop()
+
+	trace.Stop()
+
+	/*
+	 *  The names of the task and of the sweeps' log category are in
+	 *  there - not so much telling for the "sweep" regions, the GC
+	 *  has sweeps of its own.
+	 */
+	for _, name := range []string{"grim-reaper", "reaped"} {
+		if !bytes.Contains(buf.Bytes(), []byte(name)) {
+			t.Errorf("no %q in the trace", name)
+		}
+	}
+
+} /*  End of function  TestRuntimeTrace.  */
@@ -0,0 +1,128 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// How often a sweep waiting on children that are alive looks at the
+// process tree, see scanChildren.
+var childScanInterval = 1 * time.Second
+
+// Child A living process in our tree: one of our children, or one of
+// theirs and so on. Parent is our pid for our own children. Started is
+// when the process was started, as per /proc (zero if it can't tell).
+type Child struct {
+	Pid     int       `json:"pid"`
+	Parent  int       `json:"parent"`
+	Comm    string    `json:"comm"`
+	Started time.Time `json:"started"`
+}
+
+// The pid a reaper waits on, as per its config.
+func (r *Reaper) waitPid() int {
+	if r.config.TargetGroup > 0 {
+		return -r.config.TargetGroup
+	}
+
+	return r.config.Pid
+
+} /*  End of method  Reaper.waitPid.  */
+
+// Look up our process tree in /proc: our living children that we may
+// wait on (see TargetGroup and Cgroup) and all of their descendants,
+// sorted by pid.
+func (r *Reaper) children() ([]Child, error) {
+	stats, err := listProcStats()
+	if err != nil {
+		return nil, err
+	}
+
+	/*  The start times are relative to boot.  */
+	var boot time.Time
+	if uptime, err := procUptime(); err == nil {
+		boot = time.Now().Add(-uptime).Round(0)
+	}
+
+	kids := make(map[int][]procStat)
+	for _, stat := range stats {
+		if 'Z' != stat.state {
+			kids[stat.ppid] = append(kids[stat.ppid], stat)
+		}
+	}
+
+	self, pid := os.Getpid(), r.waitPid()
+
+	var tree []Child
+	var walk func(parent int)
+	walk = func(parent int) {
+		for _, stat := range kids[parent] {
+			if self == parent && (!waitMatches(stat, pid) || !r.inCgroup(stat.pid)) {
+				continue
+			}
+
+			child := Child{Pid: stat.pid, Parent: parent, Comm: stat.comm}
+			if !boot.IsZero() && stat.starttime > 0 {
+				child.Started = boot.Add(procStarted(stat))
+			}
+			tree = append(tree, child)
+			walk(stat.pid)
+		}
+	}
+	walk(self)
+
+	sort.Slice(tree, func(i, j int) bool { return tree[i].Pid < tree[j].Pid })
+	return tree, nil
+
+} /*  End of method  Reaper.children.  */
+
+// Refresh the process tree whenever the reaper wakes up (and every
+// childScanInterval while a sweep waits on living children), as per
+// Config.TrackChildren, and look for adopted orphans in it.
+func (r *Reaper) scanChildren() {
+	if !r.config.TrackChildren && !r.config.DetectAdoptions {
+		return
+	}
+
+	tree, err := r.children()
+	if err != nil {
+		return
+	}
+
+	if r.config.TrackChildren {
+		r.mu.Lock()
+		r.tree = tree
+		r.mu.Unlock()
+	}
+
+	r.scanAdoptions(tree)
+
+} /*  End of method  Reaper.scanChildren.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Children Our process tree: the living children the reaper may wait on
+// and all of their descendants, sorted by pid. With Config.TrackChildren
+// set, this is the tree as of the reaper's last look at it (kept up to
+// date while it runs), otherwise it is looked up in /proc right away. Nil
+// where there is no /proc.
+func (r *Reaper) Children() []Child {
+	if !r.config.TrackChildren {
+		tree, _ := r.children()
+		return tree
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Child(nil), r.tree...)
+
+} /*  End of [exported] method  Reaper.Children.  */
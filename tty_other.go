@@ -0,0 +1,36 @@
+//go:build !(darwin || dragonfly || freebsd || ios || linux || netbsd || openbsd)
+// +build !darwin,!dragonfly,!freebsd,!ios,!linux,!netbsd,!openbsd
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"os/exec"
+)
+
+/*  No terminal handling here, the child just shares ours.  */
+var sigWINCH os.Signal
+
+func stdinOf(cmd *exec.Cmd) (*os.File, bool) {
+	return nil, false
+
+} /*  End of function  stdinOf.  */
+
+func isTerminal(fd uintptr) bool {
+	return false
+
+} /*  End of function  isTerminal.  */
+
+func newSession(cmd *exec.Cmd) {
+} /*  End of function  newSession.  */
+
+func foreground(cmd *exec.Cmd) bool {
+	return false
+
+} /*  End of function  foreground.  */
+
+func ignoreJobControl() func() {
+	return func() {}
+
+} /*  End of function  ignoreJobControl.  */
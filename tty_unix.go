@@ -0,0 +1,100 @@
+//go:build darwin || dragonfly || freebsd || ios || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd ios linux netbsd openbsd
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// Signal telling us that the terminal was resized.
+var sigWINCH os.Signal = syscall.SIGWINCH
+
+// The command's stdin, if it is a file.
+func stdinOf(cmd *exec.Cmd) (*os.File, bool) {
+	if nil == cmd.Stdin {
+		return nil, false
+	}
+
+	file, ok := cmd.Stdin.(*os.File)
+	return file, ok
+
+} /*  End of function  stdinOf.  */
+
+// Check if fd is a terminal.
+func isTerminal(fd uintptr) bool {
+	var size [4]uint16
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size)))
+	return 0 == errno
+
+} /*  End of function  isTerminal.  */
+
+// Check if fd is our controlling terminal - only then do we get to pick
+// its foreground process group.
+func isControllingTerminal(fd uintptr) bool {
+	var pgrp int32
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGPGRP, uintptr(unsafe.Pointer(&pgrp)))
+	return 0 == errno
+
+} /*  End of function  isControllingTerminal.  */
+
+// Have the primary child start in a session of its own, with its stdin
+// as its controlling terminal if that is a terminal.
+func newSession(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setsid = true
+
+	if stdin, ok := stdinOf(cmd); ok && isTerminal(stdin.Fd()) {
+		/*  A descriptor number in the child, that's its stdin.  */
+		cmd.SysProcAttr.Setctty = true
+		cmd.SysProcAttr.Ctty = 0
+	}
+
+} /*  End of function  newSession.  */
+
+// Have the primary child start in a process group of its own, which is
+// made the foreground process group of our controlling terminal. Returns
+// false (and leaves the command be) if its stdin isn't that terminal.
+func foreground(cmd *exec.Cmd) bool {
+	stdin, ok := stdinOf(cmd)
+	if !ok || !isControllingTerminal(stdin.Fd()) {
+		return false
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	cmd.SysProcAttr.Foreground = true
+
+	/*  A descriptor number of ours, unlike for Setctty.  */
+	cmd.SysProcAttr.Ctty = int(stdin.Fd())
+	return true
+
+} /*  End of function  foreground.  */
+
+// Ignore SIGTTIN and SIGTTOU, so that touching the terminal from the
+// background doesn't stop us (or, as pid 1, have the terminal spin on
+// signals we never act on). Returns a func that has the children we
+// start from then on get the defaults again.
+func ignoreJobControl() func() {
+	signal.Ignore(syscall.SIGTTIN, syscall.SIGTTOU)
+
+	return func() {
+		/*
+		 *  signal.Reset leaves an ignored signal ignored. A handler
+		 *  of the runtime's does away with that, and is gone on exec.
+		 */
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGTTIN, syscall.SIGTTOU)
+		signal.Stop(sigs)
+	}
+
+} /*  End of function  ignoreJobControl.  */
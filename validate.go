@@ -0,0 +1,173 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrInvalidConfig The reaper's config doesn't make sense, every
+// ConfigError wraps it (see Config.Validate).
+var ErrInvalidConfig = errors.New("grim reaper: invalid config")
+
+// ConfigError A problem with a config field (or a combination of them).
+type ConfigError struct {
+	Field  string
+	Reason string
+}
+
+// Error Implement error.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%v: %s: %s", ErrInvalidConfig, e.Field, e.Reason)
+
+} /*  End of [exported] method  ConfigError.Error.  */
+
+// Unwrap Have errors.Is match ErrInvalidConfig.
+func (e *ConfigError) Unwrap() error {
+	return ErrInvalidConfig
+
+} /*  End of [exported] method  ConfigError.Unwrap.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// Validate Check the config for values and combinations of them that
+// make no sense, e.g. unknown wait4(2) option bits or negative limits.
+// Returns all the problems found, joined together: each one of them a
+// *ConfigError (see errors.As) wrapping ErrInvalidConfig. Run validates
+// the config before it starts reaping.
+func (c Config) Validate() error {
+	var errs []error
+
+	invalid := func(field, format string, args ...interface{}) {
+		errs = append(errs, &ConfigError{Field: field, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	/*  A C int, __WCLONE doesn't fit a 32 bit (Go) int as is.  */
+	if extra := uint32(c.Options) &^ waitOptions; 0 != extra {
+		invalid("Options", "unknown wait4(2) option bits %#x", extra)
+	}
+
+	reapsAsInit := !c.DisablePid1Check && !c.EnableSubreaper && 0 == c.TargetGroup
+	if reapsAsInit && 0 == c.Pid {
+		invalid("Pid", "0 only waits on our own process group, which re-parented orphans aren't in - use -1 as pid 1")
+	}
+
+	if c.StatusChannel != nil && 0 == cap(c.StatusChannel) {
+		invalid("StatusChannel", "unbuffered, all the events would be dropped")
+	}
+
+	switch c.CallbackOverflow {
+	case OverflowDropNewest, OverflowDropOldest, OverflowBlock:
+	default:
+		invalid("CallbackOverflow", "unknown policy %d", c.CallbackOverflow)
+	}
+
+	switch c.FallbackFormat {
+	case FallbackText, FallbackJSON, FallbackOff:
+	default:
+		invalid("FallbackFormat", "unknown format %d", c.FallbackFormat)
+	}
+
+	/*  In field order, so that the errors come out in that order too.  */
+	for _, field := range []struct {
+		name  string
+		value int
+	}{
+		{"CallbackWorkers", c.CallbackWorkers},
+		{"CallbackQueueSize", c.CallbackQueueSize},
+		{"MaxReaps", c.MaxReaps},
+		{"MaxWakeupsPerSecond", c.MaxWakeupsPerSecond},
+		{"NotificationQueueSize", c.NotificationQueueSize},
+		{"MaxReapLogsPerSecond", c.MaxReapLogsPerSecond},
+		{"FailureHistorySize", c.FailureHistorySize},
+		{"EventHistorySize", c.EventHistorySize},
+		{"YieldEvery", c.YieldEvery},
+		{"TargetGroup", c.TargetGroup},
+		{"EventQueueSize", c.EventQueueSize},
+		{"AuditMaxBackups", c.AuditMaxBackups},
+	} {
+		if field.value < 0 {
+			invalid(field.name, "negative (%d)", field.value)
+		}
+	}
+
+	for _, field := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"CallbackBlockTimeout", c.CallbackBlockTimeout},
+		{"StatsInterval", c.StatsInterval},
+		{"CheckpointInterval", c.CheckpointInterval},
+		{"SweepInterval", c.SweepInterval},
+		{"GracePeriod", c.GracePeriod},
+		{"HealthStaleAfter", c.HealthStaleAfter},
+	} {
+		if field.value < 0 {
+			invalid(field.name, "negative (%v)", field.value)
+		}
+	}
+
+	if c.InitSession && c.InitForeground {
+		invalid("InitForeground", "set along with InitSession, a session leader can't be moved to a process group")
+	}
+
+	switch {
+	case c.InitPTY && !ptySupported:
+		invalid("InitPTY", "not supported on %s", runtime.GOOS)
+	case c.InitPTY && (c.InitSession || c.InitForeground):
+		invalid("InitPTY", "set along with InitSession or InitForeground, the pty is the child's terminal")
+	}
+
+	if 0 != c.ParentDeathSignal && !pdeathsigSupported {
+		invalid("ParentDeathSignal", "not supported on %s", runtime.GOOS)
+	}
+
+	if c.OnStats != nil && c.StatsInterval <= 0 {
+		invalid("OnStats", "never called without a StatsInterval")
+	}
+
+	if "" != c.Cgroup && !strings.HasPrefix(c.Cgroup, "/") {
+		invalid("Cgroup", "not an absolute cgroup path (%q)", c.Cgroup)
+	}
+
+	if c.CgroupReportOnly && "" == c.Cgroup {
+		invalid("CgroupReportOnly", "set without a Cgroup")
+	}
+
+	switch c.AuditFormat {
+	case "", "json", "logfmt":
+	default:
+		invalid("AuditFormat", "unknown format %q, want json or logfmt", c.AuditFormat)
+	}
+
+	if c.AuditMaxSize < 0 {
+		invalid("AuditMaxSize", "negative (%d)", c.AuditMaxSize)
+	}
+
+	if "" != c.WebhookURL {
+		u, err := url.Parse(c.WebhookURL)
+		switch {
+		case err != nil:
+			invalid("WebhookURL", "malformed (%v)", err)
+		case "http" != u.Scheme && "https" != u.Scheme:
+			invalid("WebhookURL", "not an http(s) URL")
+		}
+	}
+
+	if c.CheckpointInterval > 0 && "" == c.CheckpointFile {
+		invalid("CheckpointInterval", "set without a CheckpointFile")
+	}
+
+	return errors.Join(errs...)
+
+} /*  End of [exported] method  Config.Validate.  */
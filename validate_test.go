@@ -0,0 +1,105 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	/*  What the reaper is mostly run with, as init and embedded.  */
+	for _, config := range []Config{
+		{Pid: -1},
+		{Pid: 0, DisablePid1Check: true},
+		{Pid: 0, EnableSubreaper: true},
+		{Pid: 0, TargetGroup: 42},
+		{Pid: -1, StatusChannel: make(chan ReapEvent, 1)},
+		{Pid: -1, Cgroup: "/system.slice/app.service", CgroupReportOnly: true},
+		{Pid: -1, AuditFormat: "logfmt", AuditMaxSize: 1 << 20, AuditMaxBackups: 3},
+		{Pid: -1, WebhookURL: "https://hooks.example.com/reaps"},
+		{Pid: -1, StatsInterval: time.Minute, OnStats: func(Stats) {}},
+		{Pid: -1, CheckpointInterval: time.Minute, CheckpointFile: "/run/reaper.json"},
+	} {
+		if err := config.Validate(); err != nil {
+			t.Errorf("%+v: %v, expected a valid config", config, err)
+		}
+	}
+
+} /*  End of function  TestValidate.  */
+
+func TestValidateRejects(t *testing.T) {
+	for _, test := range []struct {
+		field  string
+		config Config
+	}{
+		{"Options", Config{Pid: -1, Options: 1 << 20}},
+		{"Pid", Config{Pid: 0}},
+		{"StatusChannel", Config{Pid: -1, StatusChannel: make(chan ReapEvent)}},
+		{"CallbackOverflow", Config{Pid: -1, CallbackOverflow: 42}},
+		{"FallbackFormat", Config{Pid: -1, FallbackFormat: 42}},
+		{"CallbackWorkers", Config{Pid: -1, CallbackWorkers: -1}},
+		{"CallbackQueueSize", Config{Pid: -1, CallbackQueueSize: -1}},
+		{"MaxReaps", Config{Pid: -1, MaxReaps: -1}},
+		{"MaxWakeupsPerSecond", Config{Pid: -1, MaxWakeupsPerSecond: -1}},
+		{"NotificationQueueSize", Config{Pid: -1, NotificationQueueSize: -1}},
+		{"MaxReapLogsPerSecond", Config{Pid: -1, MaxReapLogsPerSecond: -1}},
+		{"FailureHistorySize", Config{Pid: -1, FailureHistorySize: -1}},
+		{"EventHistorySize", Config{Pid: -1, EventHistorySize: -1}},
+		{"YieldEvery", Config{Pid: -1, YieldEvery: -1}},
+		{"TargetGroup", Config{Pid: -1, TargetGroup: -1}},
+		{"EventQueueSize", Config{Pid: -1, EventQueueSize: -1}},
+		{"AuditMaxBackups", Config{Pid: -1, AuditMaxBackups: -1}},
+		{"CallbackBlockTimeout", Config{Pid: -1, CallbackBlockTimeout: -time.Second}},
+		{"StatsInterval", Config{Pid: -1, StatsInterval: -time.Second}},
+		{"CheckpointInterval", Config{Pid: -1, CheckpointInterval: -time.Second}},
+		{"SweepInterval", Config{Pid: -1, SweepInterval: -time.Second}},
+		{"GracePeriod", Config{Pid: -1, GracePeriod: -time.Second}},
+		{"HealthStaleAfter", Config{Pid: -1, HealthStaleAfter: -time.Second}},
+		{"InitForeground", Config{Pid: -1, InitSession: true, InitForeground: true}},
+		{"InitPTY", Config{Pid: -1, InitPTY: true, InitSession: true}},
+		{"OnStats", Config{Pid: -1, OnStats: func(Stats) {}}},
+		{"Cgroup", Config{Pid: -1, Cgroup: "system.slice"}},
+		{"CgroupReportOnly", Config{Pid: -1, CgroupReportOnly: true}},
+		{"AuditFormat", Config{Pid: -1, AuditFormat: "xml"}},
+		{"AuditMaxSize", Config{Pid: -1, AuditMaxSize: -1}},
+		{"WebhookURL", Config{Pid: -1, WebhookURL: "http://%zz"}},
+		{"WebhookURL", Config{Pid: -1, WebhookURL: "ftp://hooks.example.com"}},
+		{"CheckpointInterval", Config{Pid: -1, CheckpointInterval: time.Minute}},
+	} {
+		err := test.config.Validate()
+
+		var configErr *ConfigError
+		switch {
+		case !errors.Is(err, ErrInvalidConfig):
+			t.Errorf("%s: %v, expected an invalid config", test.field, err)
+		case !errors.As(err, &configErr) || test.field != configErr.Field:
+			t.Errorf("%s: %v, expected it to be down to %s", test.field, err, test.field)
+		}
+	}
+
+	/*  Only there where it can't be supported.  */
+	if !pdeathsigSupported {
+		if err := (Config{Pid: -1, ParentDeathSignal: 15}).Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("ParentDeathSignal on %s: %v, expected an invalid config", runtime.GOOS, err)
+		}
+	}
+
+} /*  End of function  TestValidateRejects.  */
+
+func TestValidateJoinsErrors(t *testing.T) {
+	err := Config{Pid: -1, MaxReaps: -1, GracePeriod: -time.Second}.Validate()
+
+	var fields []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var configErr *ConfigError
+		if errors.As(err, &configErr) {
+			fields = append(fields, configErr.Field)
+		}
+	}
+	if 2 != len(fields) || "MaxReaps" != fields[0] || "GracePeriod" != fields[1] {
+		t.Errorf("rejected %v, expected [MaxReaps GracePeriod]", fields)
+	}
+
+} /*  End of function  TestValidateJoinsErrors.  */
@@ -0,0 +1,85 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-kit/log/level"
+)
+
+// ErrZombiesRemain Some of our children were still zombies when the
+// reaper stopped (see Config.VerifyCleanShutdown).
+var ErrZombiesRemain = errors.New("grim reaper: zombies remain")
+
+// Find our children that are zombies, i.e. dead but not reaped.
+func zombies() ([]procStat, error) {
+	stats, err := listProcStats()
+	if err != nil {
+		return nil, err
+	}
+
+	self := os.Getpid()
+
+	var found []procStat
+	for _, stat := range stats {
+		if 'Z' == stat.state && self == stat.ppid {
+			found = append(found, stat)
+		}
+	}
+
+	return found, nil
+
+} /*  End of function  zombies.  */
+
+// Verify that the reaper left no zombies behind. Skipped where there is
+// no /proc to scan.
+func (r *Reaper) verifyCleanShutdown() error {
+	logger := r.config.Logger
+
+	found, err := zombies()
+	if err != nil {
+		level.Debug(logger).Log("msg", "skipping zombie check", "err", err)
+		return nil
+	}
+
+	if 0 == len(found) {
+		level.Debug(logger).Log("msg", "no zombies remain")
+		return nil
+	}
+
+	names := make([]string, 0, len(found))
+	for _, stat := range found {
+		names = append(names, fmt.Sprintf("%d (%s)", stat.pid, stat.comm))
+	}
+
+	zlist := strings.Join(names, ", ")
+	level.Error(logger).Log("msg", "zombies remain after shutdown", "count", len(found), "zombies", zlist)
+
+	return fmt.Errorf("%w: %s", ErrZombiesRemain, zlist)
+
+} /*  End of method  Reaper.verifyCleanShutdown.  */
+
+/*
+ *  ======================================================================
+ *  Section: Exported functions
+ *  ======================================================================
+ */
+
+// CountZombies Count the children of this process that are zombies right
+// now (state Z in /proc/<pid>/stat), i.e. dead but not reaped yet. With a
+// reaper running this should be zero, give or take a child that died just
+// now. Fails where there is no /proc to scan.
+func CountZombies() (int, error) {
+	found, err := zombies()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(found), nil
+
+} /*  End of [exported] function  CountZombies.  */
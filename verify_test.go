@@ -0,0 +1,57 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+func TestVerifyCleanShutdown(t *testing.T) {
+	proc := newFakeProc(t)
+	self := os.Getpid()
+
+	/*  Only our own dead children count.  */
+	proc.process(101, "defunct", 'Z', self, 1)
+	proc.process(102, "sleep", 'S', self, 1)
+	proc.process(103, "orphan", 'Z', 1, 1)
+
+	if count, err := CountZombies(); err != nil || 1 != count {
+		t.Errorf("counted %d zombies (err %v), expected 1", count, err)
+	}
+
+	r := New(Config{Pid: -1, DisablePid1Check: true, VerifyCleanShutdown: true, Logger: log.NewNopLogger()})
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- r.Run(context.Background())
+	}()
+	<-r.ready
+	r.Stop()
+
+	err := <-errs
+	if !errors.Is(err, ErrZombiesRemain) || !strings.Contains(err.Error(), "101 (defunct)") {
+		t.Errorf("run returned %v, expected the zombie to remain", err)
+	}
+	if strings.Contains(err.Error(), "102") || strings.Contains(err.Error(), "103") {
+		t.Errorf("run returned %v, expected just the one zombie", err)
+	}
+
+	/*  Nothing to scan, nothing to complain about.  */
+	procRoot = filepath.Join(proc.root, "missing")
+	if err := r.verifyCleanShutdown(); err != nil {
+		t.Errorf("verified with no proc to scan: %v", err)
+	}
+	if _, err := CountZombies(); nil == err {
+		t.Errorf("counted zombies with no proc to scan")
+	}
+
+} /*  End of function  TestVerifyCleanShutdown.  */
@@ -0,0 +1,63 @@
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+	"unsafe"
+)
+
+// The linux only wait4(2) options (__WALL and friends).
+const linuxWaitOptions = syscall.WALL | syscall.WCLONE | syscall.WNOTHREAD
+
+/*  waitid(2) id types, not exported by the syscall package.  */
+const (
+	pALL  = 0
+	pPID  = 1
+	pPGID = 2
+)
+
+// The SIGCHLD flavour of siginfo_t as filled in by waitid(2). The union
+// following the first three ints is pointer aligned.
+type siginfo struct {
+	Signo  int32
+	Errno  int32
+	Code   int32
+	_      [unsafe.Sizeof(uintptr(0)) - 4]byte
+	Pid    int32
+	Uid    uint32
+	Status int32
+	_      [128 - 24 - (unsafe.Sizeof(uintptr(0)) - 4)]byte
+}
+
+// Find the pid of the next waitable child matching the wait4(2) style pid
+// and options, without reaping it (WNOWAIT). Returns 0 if there is no
+// child ready and the options include WNOHANG.
+func peekChild(pid int, opts int) (int, error) {
+	idtype, id := pALL, 0
+	switch {
+	case pid > 0:
+		idtype, id = pPID, pid
+	case pid == 0:
+		/*  Our own process group.  */
+		idtype, id = pPGID, syscall.Getpgrp()
+	case pid < -1:
+		idtype, id = pPGID, -pid
+	}
+
+	/*  wait4's WUNTRACED is waitid's WSTOPPED, exits are implicit.  */
+	flags := opts | syscall.WEXITED | syscall.WNOWAIT
+
+	var info siginfo
+	for {
+		_, _, errno := syscall.Syscall6(syscall.SYS_WAITID, uintptr(idtype), uintptr(id), uintptr(unsafe.Pointer(&info)), uintptr(flags), 0, 0)
+		if syscall.EINTR == errno {
+			continue
+		}
+		if 0 != errno {
+			return 0, errno
+		}
+
+		return int(info.Pid), nil
+	}
+
+} /*  End of function  peekChild.  */
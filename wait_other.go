@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package reaper
+
+// No wait4(2) options beyond the POSIX ones.
+const linuxWaitOptions = 0
+
+// Peeking at waitable children needs waitid(2) with WNOWAIT, which we
+// only do on linux.
+func peekChild(pid int, opts int) (int, error) {
+	return 0, ErrUnsupportedPlatform
+
+} /*  End of function  peekChild.  */
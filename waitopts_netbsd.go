@@ -0,0 +1,4 @@
+package reaper
+
+/*  NetBSD has WCONTINUED, the syscall package just doesn't export it.  */
+const wCONTINUED = 0x10
@@ -0,0 +1,11 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"syscall"
+)
+
+const wCONTINUED = syscall.WCONTINUED
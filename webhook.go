@@ -0,0 +1,184 @@
+package reaper
+
+/*  Note:  This is a *nix only implementation.  */
+
+//  Prefer #include style directives.
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+const (
+	/*  Attempts at delivering an event before it is dropped.  */
+	webhookAttempts = 5
+
+	/*  How long the endpoint gets to answer a POST.  */
+	webhookTimeout = 5 * time.Second
+
+	/*  How long all of the queue gets to drain when shutting down.  */
+	webhookDrainTimeout = 5 * time.Second
+)
+
+// Backoff between attempts at delivering an event to the webhook.
+var webhookBackoff = Backoff{Initial: 500 * time.Millisecond, Max: 30 * time.Second}
+
+// webhook POSTs reap events to Config.WebhookURL. Just like for the event
+// sinks, the events are handed over via a bounded queue and delivered by
+// a goroutine of its own, so a slow or failing endpoint never holds up
+// the reap loop. Nor the shutdown: ctx is cancelled once the drain is
+// out of time, which aborts the POST in flight.
+type webhook struct {
+	url    string
+	client *http.Client
+	events chan ReapEvent
+	quit   chan struct{}
+	done   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Deliver an event, once. Returns whether a failure is worth retrying.
+func (w *webhook) post(event ReapEvent) (bool, error) {
+	data, err := json.Marshal(recordOf(event))
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case http.StatusTooManyRequests == resp.StatusCode || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+
+	/*  Anything else won't get any better by asking again.  */
+	return false, fmt.Errorf("webhook answered %s", resp.Status)
+
+} /*  End of method  webhook.post.  */
+
+// Deliver the queued events until the queue is closed, retrying with
+// backoff. An event that fails for good is dropped (and counted). Once
+// shutting down, each event gets just the one attempt and what's still
+// queued when the drain runs out of time is dropped without one.
+func (r *Reaper) runWebhook(w *webhook) {
+	defer close(w.done)
+
+	logger := r.config.Logger
+	backoff := webhookBackoff.withDefaults()
+
+	for event := range w.events {
+		if w.ctx.Err() != nil {
+			r.countStat(func(stats *Stats) { stats.DroppedWebhooks++ })
+			continue
+		}
+
+		delay := backoff.Initial
+
+		for attempt := 1; ; attempt++ {
+			retry, err := w.post(event)
+			if err == nil {
+				break
+			}
+
+			if !retry || attempt >= webhookAttempts {
+				level.Warn(logger).Log("msg", "webhook delivery failed", "pid", event.Pid, "seq", event.Seq, "attempts", attempt, "err", err)
+				r.countStat(func(stats *Stats) { stats.DroppedWebhooks++ })
+				break
+			}
+
+			select {
+			case <-w.quit:
+				r.countStat(func(stats *Stats) { stats.DroppedWebhooks++ })
+			case <-time.After(delay):
+				delay = backoff.next(delay)
+				continue
+			}
+			break
+		}
+	}
+
+} /*  End of method  Reaper.runWebhook.  */
+
+// Start the webhook, if configured.
+func (r *Reaper) startWebhook() {
+	if "" == r.config.WebhookURL {
+		return
+	}
+
+	size := r.config.EventQueueSize
+	if size <= 0 {
+		size = defaultSinkQueueSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &webhook{
+		url:    r.config.WebhookURL,
+		client: &http.Client{Timeout: webhookTimeout},
+		events: make(chan ReapEvent, size),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	go r.runWebhook(w)
+
+	r.mu.Lock()
+	r.webhook = w
+	r.mu.Unlock()
+
+} /*  End of method  Reaper.startWebhook.  */
+
+// Stop the webhook, giving it the chance to deliver what's queued - but
+// within webhookDrainTimeout all told, whatever the size of the queue.
+func (r *Reaper) stopWebhook() {
+	r.mu.Lock()
+	w := r.webhook
+	r.webhook = nil
+	r.mu.Unlock()
+
+	if w != nil {
+		drain := time.AfterFunc(webhookDrainTimeout, w.cancel)
+		close(w.quit)
+		close(w.events)
+		<-w.done
+		drain.Stop()
+		w.cancel()
+	}
+
+} /*  End of method  Reaper.stopWebhook.  */
+
+// Hand a reap to the webhook, never blocking: one that doesn't fit in the
+// queue is dropped. Caller holds the lock.
+func (r *Reaper) notifyWebhookLocked(event ReapEvent) {
+	if nil == r.webhook || EventReaped != event.Type {
+		return
+	}
+
+	select {
+	case r.webhook.events <- event:
+	default:
+		r.countStat(func(stats *Stats) { stats.DroppedWebhooks++ })
+	}
+
+} /*  End of method  Reaper.notifyWebhookLocked.  */
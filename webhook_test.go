@@ -0,0 +1,156 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A webhook endpoint, keeping count of the POSTs and the records it got.
+type fakeWebhook struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	attempts int
+	records  []eventRecord
+}
+
+// Start a fake webhook endpoint answering each POST as per answer, given
+// the number of the attempt. Shut down once the test is done.
+func newFakeWebhook(t *testing.T, answer func(attempt int) int) *fakeWebhook {
+	saved := webhookBackoff
+	webhookBackoff = Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+	t.Cleanup(func() { webhookBackoff = saved })
+
+	hook := &fakeWebhook{}
+	hook.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var record eventRecord
+		json.NewDecoder(req.Body).Decode(&record)
+
+		hook.mu.Lock()
+		hook.attempts++
+		attempt := hook.attempts
+		hook.mu.Unlock()
+
+		code := answer(attempt)
+		if http.StatusOK == code {
+			hook.mu.Lock()
+			hook.records = append(hook.records, record)
+			hook.mu.Unlock()
+		}
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(hook.Close)
+
+	return hook
+
+} /*  End of function  newFakeWebhook.  */
+
+// What the endpoint got so far: the attempts and the records delivered.
+func (hook *fakeWebhook) got() (int, []eventRecord) {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+
+	return hook.attempts, append([]eventRecord(nil), hook.records...)
+
+} /*  End of method  fakeWebhook.got.  */
+
+func TestWebhookRetry(t *testing.T) {
+	/*  Failing the first time round, fine after that.  */
+	hook := newFakeWebhook(t, func(attempt int) int {
+		if 1 == attempt {
+			return http.StatusInternalServerError
+		}
+		return http.StatusOK
+	})
+	r := startTestReaper(t, Config{WebhookURL: hook.URL})
+
+	pid := spawn(t, "exit 3")
+	eventually(t, "the delivery", func() bool {
+		_, records := hook.got()
+		return 1 == len(records)
+	})
+
+	attempts, records := hook.got()
+	if 2 != attempts || pid != records[0].Pid || nil == records[0].ExitCode || 3 != *records[0].ExitCode {
+		t.Errorf("delivered %+v in %d attempts, expected the exit of %d in 2", records[0], attempts, pid)
+	}
+	if dropped := r.Stats().DroppedWebhooks; 0 != dropped {
+		t.Errorf("dropped %d webhooks, expected none", dropped)
+	}
+
+} /*  End of function  TestWebhookRetry.  */
+
+func TestWebhookFailure(t *testing.T) {
+	for _, test := range []struct {
+		code     int
+		attempts int
+	}{
+		{http.StatusServiceUnavailable, webhookAttempts},
+		{http.StatusTooManyRequests, webhookAttempts},
+		{http.StatusBadRequest, 1},
+	} {
+		hook := newFakeWebhook(t, func(int) int { return test.code })
+		r := startTestReaper(t, Config{WebhookURL: hook.URL})
+
+		spawn(t, "exit 0")
+		eventually(t, "the drop", func() bool { return 1 == r.Stats().DroppedWebhooks })
+		if attempts, _ := hook.got(); test.attempts != attempts {
+			t.Errorf("answered %d: %d attempts, expected %d", test.code, attempts, test.attempts)
+		}
+
+		r.Stop()
+	}
+
+} /*  End of function  TestWebhookFailure.  */
+
+func TestWebhookQueueFull(t *testing.T) {
+	const children = 10
+
+	/*  Stuck on the first POST, until released.  */
+	stalled, released := make(chan struct{}), make(chan struct{})
+	hook := newFakeWebhook(t, func(attempt int) int {
+		if 1 == attempt {
+			close(stalled)
+			<-released
+		}
+		return http.StatusOK
+	})
+	t.Cleanup(func() {
+		select {
+		case <-released:
+		default:
+			close(released)
+		}
+	})
+	r := startTestReaper(t, Config{WebhookURL: hook.URL, EventQueueSize: 2})
+
+	spawn(t, "exit 0")
+	select {
+	case <-stalled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the POST")
+	}
+
+	/*  The reaping goes on, whatever doesn't fit in the queue is dropped.  */
+	for idx := 0; idx < children; idx++ {
+		spawn(t, "exit 0")
+	}
+	eventually(t, "the reaps", func() bool { return 1+children == r.Stats().Reaped })
+	eventually(t, "the drops", func() bool { return children-2 == r.Stats().DroppedWebhooks })
+
+	/*  What made it into the queue gets delivered once it moves again.  */
+	close(released)
+	eventually(t, "the deliveries", func() bool {
+		_, records := hook.got()
+		return 3 == len(records)
+	})
+
+} /*  End of function  TestWebhookQueueFull.  */
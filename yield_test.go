@@ -0,0 +1,73 @@
+//go:build darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package reaper
+
+//  Prefer #include style directives.
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// Sweeps draining a backlog of dead children (from a fake wait4) on a
+// single processor, next to a goroutine that wants to run every so
+// often. Reports the longest that goroutine had to wait for its turn.
+func benchmarkYieldEvery(b *testing.B, every int) {
+	const backlog = 1000
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	remaining := 0
+	saved := wait4
+	defer func() { wait4 = saved }()
+	wait4 = func(pid int, wstatus *syscall.WaitStatus, options int, rusage *syscall.Rusage) (int, error) {
+		if 0 == remaining {
+			return 0, nil
+		}
+		remaining--
+		return 1000 + remaining, nil
+	}
+
+	r := New(Config{Pid: -1, YieldEvery: every, Logger: log.NewNopLogger()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var longest atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for last := time.Now(); nil == ctx.Err(); runtime.Gosched() {
+			now := time.Now()
+			if gap := int64(now.Sub(last)); gap > longest.Load() {
+				longest.Store(gap)
+			}
+			last = now
+		}
+	}()
+
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		remaining = backlog
+		r.sweepWith(ctx, ctx, nil, wNOHANG)
+	}
+	b.StopTimer()
+
+	cancel()
+	<-done
+
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*backlog), "ns/reap")
+	b.ReportMetric(float64(longest.Load()), "max-wait-ns")
+
+} /*  End of function  benchmarkYieldEvery.  */
+
+func BenchmarkYieldEvery(b *testing.B) {
+	b.Run("never", func(b *testing.B) { benchmarkYieldEvery(b, 0) })
+	b.Run("16", func(b *testing.B) { benchmarkYieldEvery(b, 16) })
+	b.Run("1", func(b *testing.B) { benchmarkYieldEvery(b, 1) })
+
+} /*  End of function  BenchmarkYieldEvery.  */